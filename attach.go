@@ -0,0 +1,75 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vpphelper
+
+import (
+	"context"
+	"os"
+
+	"github.com/edwarnicke/log"
+	"go.fd.io/govpp"
+)
+
+// WithAttachOrStart - before starting vpp, checks whether a live vpp already answers a control_ping on the api
+// socket in the root dir.  If one does, StartContext attaches to it instead of starting its own (see
+// Instance.Attached): the config files are left untouched and the attached vpp is never stopped or restarted.
+// It cannot be combined with WithStartupCLI.
+func WithAttachOrStart() Option {
+	return func(opt *option) {
+		opt.attachOrStart = true
+	}
+}
+
+// probeVPP - returns nil if a live vpp answers a control_ping on socket
+func probeVPP(ctx context.Context, socket string) error {
+	if _, err := os.Stat(socket); err != nil {
+		return err
+	}
+	conn, err := govpp.Connect(socket)
+	if err != nil {
+		return err
+	}
+	defer conn.Disconnect()
+	return runReadinessChecks(ctx, conn, []ReadinessCheck{ControlPingCheck()})
+}
+
+// attach - returns an Instance for the vpp answering on the api socket in paths.  It is done once ctx is done or
+// Stop is called.
+func attach(ctx context.Context, paths Paths) *Instance {
+	log.Entry(ctx).Infof("attaching to the vpp running on %s", paths.APISocket)
+	i := &Instance{
+		paths:    paths,
+		attached: true,
+		done:     make(chan struct{}),
+	}
+	var vppCtx context.Context
+	vppCtx, i.cancel = context.WithCancel(ctx)
+	// The connection is disconnected rather than torn down by Stop cancelling vppCtx, so that its Err() is ErrClosed
+	i.conn = DialContext(context.WithoutCancel(vppCtx), paths.APISocket)
+	go func() {
+		<-vppCtx.Done()
+		i.conn.Disconnect()
+		close(i.done)
+	}()
+	return i
+}
+
+// Attached - returns true if the Instance attached to an already running vpp (see WithAttachOrStart) and false if
+// it started vpp itself
+func (i *Instance) Attached() bool {
+	return i.attached
+}
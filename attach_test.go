@@ -0,0 +1,66 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vpphelper_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.fd.io/govpp/binapi/memclnt"
+
+	"github.com/networkservicemesh/vpphelper"
+)
+
+func TestWithAttachOrStart_Attach(t *testing.T) {
+	_, _ = mockVPP(t)
+	// Starting vpp would fail the test
+	fakeVPP(t, "exit 1")
+	rootDir := t.TempDir()
+	socket := filepath.Join(rootDir, "/var/run/vpp/api.sock")
+	require.NoError(t, os.MkdirAll(filepath.Dir(socket), 0o700))
+	require.NoError(t, os.WriteFile(socket, nil, 0o600))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	instance, err := vpphelper.StartContext(ctx, vpphelper.WithRootDir(rootDir), vpphelper.WithAttachOrStart())
+	require.NoError(t, err)
+	require.True(t, instance.Attached())
+	require.Zero(t, instance.PID())
+	require.NoFileExists(t, instance.Paths().ConfigFile)
+	require.NoError(t, instance.Conn().Invoke(ctx, &memclnt.ControlPing{}, &memclnt.ControlPingReply{}))
+
+	require.NoError(t, instance.Stop(ctx))
+	require.NoError(t, instance.Wait())
+	require.ErrorIs(t, instance.Conn().Err(), vpphelper.ErrClosed)
+}
+
+func TestWithAttachOrStart_Start(t *testing.T) {
+	_, _ = mockVPP(t)
+	fakeVPP(t, "exec sleep 60")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	instance, err := vpphelper.StartContext(ctx, vpphelper.WithRootDir(t.TempDir()), vpphelper.WithAttachOrStart())
+	require.NoError(t, err)
+	require.False(t, instance.Attached())
+	require.Positive(t, instance.PID())
+	require.NoError(t, instance.Stop(ctx))
+}
@@ -0,0 +1,237 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vpphelper
+
+import (
+	"bufio"
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/edwarnicke/log"
+	"github.com/pkg/errors"
+)
+
+// WithCgroup - runs vpp in the cgroup v2 at path, which is created if needed.  A relative path is taken relative
+// to the cgroup of the calling process.  The limits are set with WithCPUSet, WithMemoryMax and WithCPUWeight.
+// If cgroupfs is read-only vpp runs in the cgroup of the calling process and a warning is logged.
+// vpp is started in the cgroup (CLONE_INTO_CGROUP, Linux 5.7+), so nothing it forks escapes it.
+// Limits need their controllers enabled in every cgroup above path, which is done up to the first one that has them
+// enabled already, usually the root of a delegated subtree.  A cgroup v2 that has processes cannot enable controllers
+// for its children, so with limits a relative path only works if the calling process is in the root cgroup: use a
+// path next to the cgroup of the calling process instead.
+func WithCgroup(path string) Option {
+	return func(opt *option) {
+		opt.cgroup = path
+	}
+}
+
+// WithCPUSet - sets cpuset.cpus of the cgroup of vpp (see WithCgroup), for example "2-3"
+func WithCPUSet(cpus string) Option {
+	return func(opt *option) {
+		opt.cpuSet = cpus
+	}
+}
+
+// WithMemoryMax - sets memory.max of the cgroup of vpp (see WithCgroup) in bytes
+func WithMemoryMax(bytes int64) Option {
+	return func(opt *option) {
+		opt.memoryMax = bytes
+	}
+}
+
+// WithCPUWeight - sets cpu.weight of the cgroup of vpp (see WithCgroup), from 1 to 10000
+func WithCPUWeight(weight uint64) Option {
+	return func(opt *option) {
+		opt.cpuWeight = weight
+	}
+}
+
+// cgroup - cgroup v2 the vpp processes of an Instance are started in
+type cgroup struct {
+	path string
+}
+
+// newCgroup - creates and configures the cgroup requested by o.  It returns nil if no cgroup was requested or
+// cgroupfs is read-only.
+func newCgroup(ctx context.Context, o *option) (*cgroup, error) {
+	if o.cgroup == "" {
+		return nil, nil
+	}
+	path := o.cgroup
+	if !filepath.IsAbs(path) {
+		base, err := ownCgroup()
+		if err != nil {
+			return nil, err
+		}
+		path = filepath.Join(base, path)
+	}
+
+	err := setupCgroup(path, o)
+	if errors.Is(err, syscall.EROFS) {
+		log.Entry(ctx).Warnf("cgroupfs is read-only, not running vpp in cgroup %s: %v", path, err)
+		return nil, nil
+	}
+	if err != nil {
+		_ = os.Remove(path)
+		return nil, err
+	}
+	log.Entry(ctx).Infof("running vpp in cgroup %s", path)
+	return &cgroup{path: path}, nil
+}
+
+func setupCgroup(path string, o *option) error {
+	if err := os.MkdirAll(path, 0o755); err != nil { // #nosec G301
+		return errors.Wrapf(err, "unable to create cgroup %s", path)
+	}
+	var controllers []string
+	var settings [][2]string
+	if o.cpuSet != "" {
+		controllers = append(controllers, "cpuset")
+		settings = append(settings, [2]string{"cpuset.cpus", o.cpuSet})
+	}
+	if o.memoryMax > 0 {
+		controllers = append(controllers, "memory")
+		settings = append(settings, [2]string{"memory.max", strconv.FormatInt(o.memoryMax, 10)})
+	}
+	if o.cpuWeight > 0 {
+		controllers = append(controllers, "cpu")
+		settings = append(settings, [2]string{"cpu.weight", strconv.FormatUint(o.cpuWeight, 10)})
+	}
+	if len(controllers) > 0 {
+		if err := enableControllers(filepath.Dir(path), controllers); err != nil {
+			return err
+		}
+	}
+	for _, setting := range settings {
+		if err := writeCgroupFile(path, setting[0], setting[1]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeCgroupFile(dir, name, value string) error {
+	filename := filepath.Join(dir, name)
+	return errors.Wrapf(os.WriteFile(filename, []byte(value), 0o644), "unable to write %q to %s", value, filename) // #nosec G306
+}
+
+// enableControllers - enables controllers for the children of the cgroup dir and of every cgroup above it, up to
+// the first one that has them enabled already
+func enableControllers(dir string, controllers []string) error {
+	var dirs []string
+	for ; !subtreeControls(dir, controllers); dir = filepath.Dir(dir) {
+		if _, err := os.Stat(filepath.Join(dir, "cgroup.controllers")); err != nil || dir == filepath.Dir(dir) {
+			break
+		}
+		dirs = append(dirs, dir)
+	}
+	value := "+" + strings.Join(controllers, " +")
+	for idx := len(dirs) - 1; idx >= 0; idx-- {
+		err := writeCgroupFile(dirs[idx], "cgroup.subtree_control", value)
+		if errors.Is(err, syscall.EBUSY) {
+			return errors.Wrapf(err, "cgroup %s has processes, so it cannot enable controllers for its children: "+
+				"the cgroup of vpp has to be outside of it", dirs[idx])
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// subtreeControls - returns whether the cgroup dir has all of controllers enabled for its children
+func subtreeControls(dir string, controllers []string) bool {
+	data, err := os.ReadFile(filepath.Clean(filepath.Join(dir, "cgroup.subtree_control")))
+	if err != nil {
+		return false
+	}
+	enabled := strings.Fields(string(data))
+	for _, controller := range controllers {
+		if !slices.Contains(enabled, controller) {
+			return false
+		}
+	}
+	return true
+}
+
+// open - opens the cgroup directory, for starting vpp in it
+func (c *cgroup) open() (*os.File, error) {
+	f, err := os.Open(c.path)
+	return f, errors.Wrapf(err, "unable to open cgroup %s", c.path)
+}
+
+// remove - removes the cgroup once all its processes exited
+func (c *cgroup) remove() {
+	_ = os.Remove(c.path)
+}
+
+// ownCgroup - returns the directory of the cgroup v2 of the calling process
+func ownCgroup() (string, error) {
+	mountPoint, err := cgroup2MountPoint()
+	if err != nil {
+		return "", err
+	}
+	data, err := os.ReadFile("/proc/self/cgroup")
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		if path, ok := strings.CutPrefix(line, "0::"); ok {
+			return filepath.Join(mountPoint, path), nil
+		}
+	}
+	return "", errors.New("the calling process is not in a cgroup v2")
+}
+
+func cgroup2MountPoint() (string, error) {
+	f, err := os.Open("/proc/self/mountinfo")
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+	defer func() { _ = f.Close() }()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		// <id> <parent> <major:minor> <root> <mount point> <options> [<optional fields>] - <fs type> <source> ...
+		fields := strings.Fields(scanner.Text())
+		for idx := 6; idx+1 < len(fields); idx++ {
+			if fields[idx] == "-" {
+				if fields[idx+1] == "cgroup2" {
+					return fields[4], nil
+				}
+				break
+			}
+		}
+	}
+	if err = scanner.Err(); err != nil {
+		return "", errors.WithStack(err)
+	}
+	return "", errors.New("no cgroup2 filesystem is mounted")
+}
+
+// CgroupPath - returns the cgroup vpp runs in (see WithCgroup), an empty string if it runs in the cgroup of the
+// calling process
+func (i *Instance) CgroupPath() string {
+	if i.cgroup == nil {
+		return ""
+	}
+	return i.cgroup.path
+}
@@ -0,0 +1,165 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+
+package vpphelper_test
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/vpphelper"
+)
+
+// testCgroup - creates a cgroup v2 for the test, which is removed once the test is done, and skips the test if
+// cgroup v2 is not mounted or not writable
+func testCgroup(t *testing.T) string {
+	mountinfo, err := os.ReadFile("/proc/self/mountinfo")
+	require.NoError(t, err)
+	for _, line := range strings.Split(string(mountinfo), "\n") {
+		// The filesystem type follows the " - " separator
+		fields := strings.Fields(line)
+		if idx := slices.Index(fields, "-"); idx > 4 && idx+1 < len(fields) && fields[idx+1] == "cgroup2" {
+			dir, err := os.MkdirTemp(fields[4], "vpphelper-test-")
+			if err != nil {
+				t.Skipf("unable to create a cgroup: %v", err)
+			}
+			t.Cleanup(func() { _ = os.Remove(dir) })
+			return dir
+		}
+	}
+	t.Skip("cgroup v2 is not mounted")
+	return ""
+}
+
+// requireControllers - skips the test unless controllers are available in the cgroup dir
+func requireControllers(t *testing.T, dir string, controllers ...string) {
+	available := strings.Fields(readFile(t, filepath.Join(dir, "cgroup.controllers")))
+	for _, controller := range controllers {
+		if !slices.Contains(available, controller) {
+			t.Skipf("the %s controller is not available", controller)
+		}
+	}
+}
+
+func TestWithCgroup(t *testing.T) {
+	cgroup := filepath.Join(testCgroup(t), "vpp")
+	_, _ = mockVPP(t)
+	childPIDFile := filepath.Join(t.TempDir(), "child.pid")
+	forkingVPP(t, childPIDFile, "exec sleep 60")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	instance, err := vpphelper.StartContext(ctx, vpphelper.WithRootDir(t.TempDir()), vpphelper.WithCgroup(cgroup))
+	require.NoError(t, err)
+	defer func() { _ = instance.Stop(ctx) }()
+
+	// vpp is started in the cgroup, so the child it forks right away is in there too
+	childPID := readPID(t, childPIDFile)
+	require.Equal(t, cgroup, instance.CgroupPath())
+	require.ElementsMatch(t, []string{strconv.Itoa(instance.PID()), strconv.Itoa(childPID)},
+		strings.Fields(readFile(t, filepath.Join(cgroup, "cgroup.procs"))))
+
+	require.NoError(t, instance.Stop(ctx))
+	require.NoDirExists(t, cgroup)
+}
+
+func TestWithCgroup_Limits(t *testing.T) {
+	parent := testCgroup(t)
+	requireControllers(t, filepath.Dir(parent), "cpuset", "memory", "cpu")
+	cgroup := filepath.Join(parent, "limited", "vpp")
+	t.Cleanup(func() { _ = os.Remove(filepath.Dir(cgroup)) })
+	_, _ = mockVPP(t)
+	fakeVPP(t, "exec sleep 60")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	instance, err := vpphelper.StartContext(ctx,
+		vpphelper.WithRootDir(t.TempDir()),
+		vpphelper.WithCgroup(cgroup),
+		vpphelper.WithCPUSet("0"),
+		vpphelper.WithMemoryMax(1<<30),
+		vpphelper.WithCPUWeight(50),
+	)
+	require.NoError(t, err)
+	defer func() { _ = instance.Stop(ctx) }()
+
+	// The controllers are enabled along the whole path
+	for _, dir := range []string{parent, filepath.Dir(cgroup)} {
+		require.Subset(t, strings.Fields(readFile(t, filepath.Join(dir, "cgroup.subtree_control"))),
+			[]string{"cpuset", "memory", "cpu"})
+	}
+	require.Equal(t, "0", strings.TrimSpace(readFile(t, filepath.Join(cgroup, "cpuset.cpus"))))
+	require.Equal(t, "1073741824", strings.TrimSpace(readFile(t, filepath.Join(cgroup, "memory.max"))))
+	require.Equal(t, "50", strings.TrimSpace(readFile(t, filepath.Join(cgroup, "cpu.weight"))))
+}
+
+func TestWithCgroup_ParentHasProcesses(t *testing.T) {
+	parent := testCgroup(t)
+	requireControllers(t, filepath.Dir(parent), "cpu")
+	sleep := exec.Command("sleep", "60")
+	require.NoError(t, sleep.Start())
+	t.Cleanup(func() {
+		_ = sleep.Process.Kill()
+		_ = sleep.Wait()
+	})
+	require.NoError(t, os.WriteFile(filepath.Join(parent, "cgroup.procs"), []byte(strconv.Itoa(sleep.Process.Pid)), 0o600))
+	_, _ = mockVPP(t)
+	fakeVPP(t, "exec sleep 60")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_, err := vpphelper.StartContext(ctx,
+		vpphelper.WithRootDir(t.TempDir()),
+		vpphelper.WithCgroup(filepath.Join(parent, "vpp")),
+		vpphelper.WithCPUWeight(50),
+	)
+	require.ErrorIs(t, err, syscall.EBUSY)
+	require.ErrorContains(t, err, "cgroup "+parent+" has processes")
+	require.NoDirExists(t, filepath.Join(parent, "vpp"))
+}
+
+func TestWithCgroup_ReadOnly(t *testing.T) {
+	cgroupfs := t.TempDir()
+	if err := syscall.Mount("tmpfs", cgroupfs, "tmpfs", syscall.MS_RDONLY, ""); err != nil {
+		t.Skipf("unable to mount a read-only filesystem: %v", err)
+	}
+	t.Cleanup(func() { _ = syscall.Unmount(cgroupfs, 0) })
+	_, _ = mockVPP(t)
+	fakeVPP(t, "exec sleep 60")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	instance, err := vpphelper.StartContext(ctx,
+		vpphelper.WithRootDir(t.TempDir()),
+		vpphelper.WithCgroup(filepath.Join(cgroupfs, "vpp")),
+		vpphelper.WithMemoryMax(1<<30),
+	)
+	require.NoError(t, err)
+	require.Empty(t, instance.CgroupPath())
+	require.NoError(t, instance.Stop(ctx))
+}
@@ -0,0 +1,105 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vpphelper
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/edwarnicke/log"
+)
+
+const shmDir = "/dev/shm"
+
+var (
+	// shmSegmentNames - shared memory segments created by vpp, prefixed with "<prefix>-" if api-segment sets a prefix
+	shmSegmentNames          = []string{"global_vm", "vpe-api"}
+	apiSegmentPrefixRegexp   = regexp.MustCompile(`api-segment\s*{[^}]*\bprefix\s+([^\s}]+)`)
+	vppConfCommentLineRegexp = regexp.MustCompile(`(?m)#.*$`)
+)
+
+// WithKeepRuntimeFiles - keeps the api, cli and stats sockets and the shared memory segments of vpp once it
+// exited.  By default they are removed, as they are before vpp is started.
+func WithKeepRuntimeFiles() Option {
+	return func(opt *option) {
+		opt.keepRuntimeFiles = true
+	}
+}
+
+// removeRuntimeFiles - removes the sockets and shared memory segments of the vpp in paths.  Shared memory segments
+// are only removed if vpp.conf sets an api-segment prefix and no process maps them, a check that cannot see
+// processes in other pid namespaces.
+func removeRuntimeFiles(ctx context.Context, paths Paths) {
+	files := []string{paths.APISocket, paths.CLISocket, paths.StatsSocket}
+	segments := shmSegments(paths.ConfigFile)
+	mapped := mappedFiles(segments)
+	for _, segment := range segments {
+		if mapped[segment] {
+			log.Entry(ctx).Debugf("not removing %s, it is still in use", segment)
+			continue
+		}
+		files = append(files, segment)
+	}
+	for _, filename := range files {
+		err := os.Remove(filename)
+		switch {
+		case err == nil:
+			log.Entry(ctx).Debugf("removed %s", filename)
+		case !os.IsNotExist(err):
+			log.Entry(ctx).Warnf("unable to remove %s due to %+v", filename, err)
+		}
+	}
+}
+
+// shmSegments - returns the shared memory segments of the vpp configured in configFile.  Without an api-segment
+// prefix the segments are shared by every vpp on the host that has none either, so none are returned.
+func shmSegments(configFile string) []string {
+	config, err := os.ReadFile(filepath.Clean(configFile))
+	if err != nil {
+		return nil
+	}
+	match := apiSegmentPrefixRegexp.FindSubmatch(vppConfCommentLineRegexp.ReplaceAll(config, nil))
+	if match == nil {
+		return nil
+	}
+	segments := make([]string, 0, len(shmSegmentNames))
+	for _, name := range shmSegmentNames {
+		segments = append(segments, filepath.Join(shmDir, string(match[1])+"-"+name))
+	}
+	return segments
+}
+
+// mappedFiles - returns which of filenames are mapped by any process
+func mappedFiles(filenames []string) map[string]bool {
+	mapped := make(map[string]bool)
+	maps, _ := filepath.Glob("/proc/[0-9]*/maps")
+	for _, m := range maps {
+		data, err := os.ReadFile(filepath.Clean(m))
+		if err != nil {
+			continue
+		}
+		for _, filename := range filenames {
+			if strings.Contains(string(data), filename) {
+				mapped[filename] = true
+			}
+		}
+	}
+	return mapped
+}
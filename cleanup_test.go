@@ -0,0 +1,111 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vpphelper_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/vpphelper"
+)
+
+// runtimeFiles - returns the sockets in rootDir and the shared memory segments of a vpp using prefix as its
+// api-segment prefix
+func runtimeFiles(rootDir, prefix string) []string {
+	return []string{
+		filepath.Join(rootDir, "/var/run/vpp/api.sock"),
+		filepath.Join(rootDir, "/var/run/vpp/cli.sock"),
+		filepath.Join(rootDir, "/var/run/vpp/stats.sock"),
+		filepath.Join("/dev/shm", prefix+"-global_vm"),
+		filepath.Join("/dev/shm", prefix+"-vpe-api"),
+	}
+}
+
+func startWithRuntimeFiles(t *testing.T, opts ...vpphelper.Option) []string {
+	if _, err := os.Stat("/dev/shm"); err != nil {
+		t.Skip("/dev/shm is not available")
+	}
+	_, _ = mockVPP(t)
+	rootDir := t.TempDir()
+	prefix := "vpphelper-test-" + filepath.Base(rootDir)
+	files := runtimeFiles(rootDir, prefix)
+	t.Cleanup(func() {
+		for _, filename := range files {
+			_ = os.Remove(filename)
+		}
+	})
+
+	// The files left behind by an earlier vpp are gone before vpp starts, the ones of this vpp are created by it
+	var script strings.Builder
+	for _, filename := range files {
+		require.NoError(t, os.MkdirAll(filepath.Dir(filename), 0o700))
+		require.NoError(t, os.WriteFile(filename, nil, 0o600))
+		script.WriteString("[ -e " + filename + " ] && exit 1\n")
+	}
+	fakeVPP(t, script.String()+"touch "+strings.Join(files, " ")+"\nexec sleep 60")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	vppConfig := strings.Replace(vpphelper.DefaultVPPConfTemplate, "api-segment {", "api-segment {\n  prefix "+prefix, 1)
+	opts = append([]vpphelper.Option{vpphelper.WithRootDir(rootDir), vpphelper.WithVppConfig(vppConfig)}, opts...)
+	instance, err := vpphelper.StartContext(ctx, opts...)
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		_, statErr := os.Stat(files[len(files)-1])
+		return statErr == nil
+	}, time.Second, 10*time.Millisecond)
+	require.NoError(t, instance.Stop(ctx))
+	return files
+}
+
+func TestStartContext_RemovesRuntimeFiles(t *testing.T) {
+	for _, filename := range startWithRuntimeFiles(t) {
+		require.NoFileExists(t, filename)
+	}
+}
+
+func TestWithKeepRuntimeFiles(t *testing.T) {
+	for _, filename := range startWithRuntimeFiles(t, vpphelper.WithKeepRuntimeFiles()) {
+		require.FileExists(t, filename)
+	}
+}
+
+func TestStartContext_KeepsUnprefixedSegments(t *testing.T) {
+	segment := "/dev/shm/global_vm"
+	if _, err := os.Stat(segment); os.IsNotExist(err) {
+		if err = os.WriteFile(segment, nil, 0o600); err != nil {
+			t.Skipf("unable to create %s: %v", segment, err)
+		}
+		t.Cleanup(func() { _ = os.Remove(segment) })
+	}
+	_, _ = mockVPP(t)
+	fakeVPP(t, "exec sleep 60")
+
+	// the default template sets no api-segment prefix, global_vm may belong to any vpp on the host
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	instance, err := vpphelper.StartContext(ctx, vpphelper.WithRootDir(t.TempDir()))
+	require.NoError(t, err)
+	require.NoError(t, instance.Stop(ctx))
+	require.FileExists(t, segment)
+}
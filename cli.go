@@ -0,0 +1,81 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vpphelper
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+	"go.fd.io/govpp/api"
+	"go.fd.io/govpp/binapi/vlib"
+)
+
+// cliErrorRegexp - matches the output of a failed vpp cli command: either "unknown input ..." for a command vpp does
+// not know or "<command path>: <error>" in the first line
+var cliErrorRegexp = regexp.MustCompile(`(?i)\A\s*(unknown input|[^:\n]+:[^\n]*\b(unknown|invalid|failed|error|not found|no such)\b)`)
+
+// CLIError - a vpp cli command failed
+type CLIError struct {
+	Command string
+	// Output - the output of the command
+	Output string
+}
+
+func (e *CLIError) Error() string {
+	return fmt.Sprintf("vpp cli command %q failed: %s", e.Command, strings.TrimSpace(e.Output))
+}
+
+// cliError - returns a *CLIError if output is the output of a failed cli command, nil otherwise
+func cliError(command, output string) *CLIError {
+	if !cliErrorRegexp.MatchString(output) {
+		return nil
+	}
+	return &CLIError{Command: command, Output: output}
+}
+
+// RunCLI - runs cmd with the vpp cli over the binary api (cli_inband) and returns its output.  If the output is
+// that of a failed cli command a *CLIError is returned along with it.
+func RunCLI(ctx context.Context, conn api.Connection, cmd string) (string, error) {
+	reply, err := vlib.NewServiceClient(conn).CliInband(ctx, &vlib.CliInband{Cmd: cmd})
+	if err != nil {
+		return "", errors.Wrapf(err, "cli_inband %q failed", cmd)
+	}
+	if cliErr := cliError(cmd, reply.Reply); cliErr != nil {
+		return reply.Reply, cliErr
+	}
+	return reply.Reply, nil
+}
+
+// RunCLIBatch - runs cmds one after the other with RunCLI and returns their outputs.  It stops at the first command
+// that fails, the outputs returned end with that of the failed command.
+func RunCLIBatch(ctx context.Context, conn api.Connection, cmds ...string) ([]string, error) {
+	outputs := make([]string, 0, len(cmds))
+	for _, cmd := range cmds {
+		output, err := RunCLI(ctx, conn, cmd)
+		if err != nil {
+			if output != "" {
+				outputs = append(outputs, output)
+			}
+			return outputs, err
+		}
+		outputs = append(outputs, output)
+	}
+	return outputs, nil
+}
@@ -0,0 +1,163 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package cli - provides a client for the vpp debug cli listening on a unix socket (cli-listen in vpp.conf), which
+// keeps working when the binary api is wedged
+package cli
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// DefaultPrompt - prompt of the vpp cli, unless changed with cli-prompt in vpp.conf
+const DefaultPrompt = "vpp# "
+
+// ansiEscapeRegexp - matches the ANSI escape sequences vpp may send despite the dumb terminal type
+var ansiEscapeRegexp = regexp.MustCompile(`\x1b\[[0-9;?]*[A-Za-z]`)
+
+// Option - option for Dial
+type Option func(c *Client)
+
+// WithPrompt - sets the prompt vpp ends its output with, DefaultPrompt by default
+func WithPrompt(prompt string) Option {
+	return func(c *Client) {
+		c.prompt = prompt
+	}
+}
+
+// Client - session with the vpp cli.  It is safe for concurrent use, commands are run one at a time.
+type Client struct {
+	prompt string
+
+	mu     sync.Mutex
+	conn   net.Conn
+	telnet *telnet
+	buf    []byte
+}
+
+// Dial - connects to the vpp cli listening on socket and waits for its first prompt
+func Dial(ctx context.Context, socket string, opts ...Option) (*Client, error) {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "unix", socket)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to connect to the vpp cli at %s", socket)
+	}
+	c := &Client{
+		prompt: DefaultPrompt,
+		conn:   conn,
+		telnet: newTelnet(conn),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if _, err = c.readPrompt(ctx); err != nil {
+		_ = conn.Close()
+		return nil, errors.Wrapf(err, "no prompt from the vpp cli at %s", socket)
+	}
+	return c, nil
+}
+
+// Run - runs cmd and returns its output, without the echoed command and the prompt
+func (c *Client) Run(ctx context.Context, cmd string) (string, error) {
+	if strings.ContainsAny(cmd, "\r\n") {
+		return "", errors.Errorf("cli command %q spans several lines", cmd)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stop := c.watch(ctx)
+	_, err := c.conn.Write([]byte(cmd + "\n"))
+	stop()
+	if err != nil {
+		return "", errors.Wrapf(ctxErr(ctx, err), "unable to send %q to the vpp cli", cmd)
+	}
+	output, err := c.readPrompt(ctx)
+	if err != nil {
+		return "", errors.Wrapf(err, "unable to read the output of %q from the vpp cli", cmd)
+	}
+	// vpp echoes what it receives, the output starts after the echoed command
+	if rest, ok := strings.CutPrefix(output, cmd); ok {
+		output = strings.TrimPrefix(rest, "\n")
+	}
+	return output, nil
+}
+
+// Close - closes the session
+func (c *Client) Close() error {
+	return errors.WithStack(c.conn.Close())
+}
+
+// readPrompt - reads until the prompt and returns what came before it, with telnet commands, ANSI escape sequences
+// and carriage returns removed
+func (c *Client) readPrompt(ctx context.Context) (string, error) {
+	stop := c.watch(ctx)
+	defer stop()
+	chunk := make([]byte, 4096)
+	for {
+		output := ansiEscapeRegexp.ReplaceAll(bytes.ReplaceAll(c.buf, []byte("\r"), nil), nil)
+		// The output itself may contain the prompt, only the last one ends it
+		if idx := bytes.LastIndex(output, []byte(c.prompt)); idx >= 0 && len(bytes.TrimSpace(output[idx+len(c.prompt):])) == 0 {
+			c.buf = c.buf[:0]
+			return string(output[:idx]), nil
+		}
+		n, err := c.conn.Read(chunk)
+		if n > 0 {
+			data, filterErr := c.telnet.filter(chunk[:n])
+			if filterErr != nil {
+				return "", ctxErr(ctx, filterErr)
+			}
+			c.buf = append(c.buf, data...)
+		}
+		if err != nil {
+			return "", errors.WithStack(ctxErr(ctx, err))
+		}
+	}
+}
+
+// watch - interrupts reads and writes on the connection once ctx is done.  The returned function stops watching.
+func (c *Client) watch(ctx context.Context) (stop func()) {
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		select {
+		case <-ctx.Done():
+			_ = c.conn.SetDeadline(time.Unix(1, 0))
+		case <-done:
+		}
+	}()
+	return func() {
+		close(done)
+		<-stopped
+		_ = c.conn.SetDeadline(time.Time{})
+	}
+}
+
+// ctxErr - returns the error of ctx if it interrupted an operation that failed with err
+func ctxErr(ctx context.Context, err error) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return err
+}
@@ -0,0 +1,199 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli_test
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"net"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+
+	"github.com/networkservicemesh/vpphelper/cli"
+)
+
+const (
+	iac      = 255
+	do       = 253
+	will     = 251
+	sb       = 250
+	se       = 240
+	optEcho  = 1
+	optSGA   = 3
+	optTTYPE = 24
+	optNAWS  = 31
+)
+
+// fakeCLI - a vpp cli the way it talks to a telnet client: it negotiates options, echoes the commands it receives
+// and ends every output with a prompt
+type fakeCLI struct {
+	prompt  string
+	outputs map[string]string
+	// negotiation - the telnet commands the client answered with
+	negotiation chan []byte
+}
+
+func serveFakeCLI(t *testing.T, prompt string, outputs map[string]string) (socket string, f *fakeCLI) {
+	socket = filepath.Join(t.TempDir(), "cli.sock")
+	listener, err := net.Listen("unix", socket)
+	require.NoError(t, err)
+	f = &fakeCLI{prompt: prompt, outputs: outputs, negotiation: make(chan []byte, 1)}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer func() { _ = conn.Close() }()
+		f.serve(conn)
+	}()
+	t.Cleanup(func() {
+		_ = listener.Close()
+		<-done
+	})
+	return socket, f
+}
+
+func (f *fakeCLI) serve(conn net.Conn) {
+	r := bufio.NewReader(conn)
+	_, _ = conn.Write([]byte{iac, will, optEcho, iac, will, optSGA, iac, do, optTTYPE, iac, do, optNAWS})
+
+	// do echo, do sga, will ttype, will naws, the window size and the terminal type make six answers
+	var negotiation []byte
+	for answers := 0; answers < 6; answers++ {
+		command, err := readTelnet(r)
+		if err != nil {
+			return
+		}
+		negotiation = append(negotiation, command...)
+		if bytes.Equal(command, []byte{iac, will, optTTYPE}) {
+			_, _ = conn.Write([]byte{iac, sb, optTTYPE, 1, iac, se})
+		}
+	}
+	f.negotiation <- negotiation
+
+	_, _ = conn.Write([]byte("\x1b[0m    _______    _        _   _____  ___ \r\n vpp banner\r\n\r\n" + f.prompt))
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		cmd := strings.TrimRight(line, "\r\n")
+		output := strings.ReplaceAll(f.outputs[cmd], "\n", "\r\n")
+		output = strings.ReplaceAll(output, "\xff", "\xff\xff")
+		// split the answer to make the client put it back together
+		_, _ = conn.Write([]byte(cmd + "\r\n" + output[:len(output)/2]))
+		_, _ = conn.Write([]byte(output[len(output)/2:] + f.prompt))
+	}
+}
+
+// readTelnet - reads a telnet command: IAC <verb> <option> or IAC SB ... IAC SE
+func readTelnet(r *bufio.Reader) ([]byte, error) {
+	command := make([]byte, 3)
+	if _, err := r.Read(command[:1]); err != nil {
+		return nil, err
+	}
+	for idx := 1; idx < 3; idx++ {
+		b, err := r.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+		command[idx] = b
+	}
+	if command[1] != sb {
+		return command, nil
+	}
+	for !bytes.HasSuffix(command, []byte{iac, se}) {
+		b, err := r.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+		command = append(command, b)
+	}
+	return command, nil
+}
+
+func TestClient(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+	socket, f := serveFakeCLI(t, cli.DefaultPrompt, map[string]string{
+		"show version":   "vpp v24.10-release built by root\n",
+		"show interface": "local0  0  down\ntap0  1  up\n",
+		"show hexdump":   "00 ff \xff\n",
+		"show history":   "vpp# show version\nvpp# show interface\n",
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	client, err := cli.Dial(ctx, socket)
+	require.NoError(t, err)
+	defer func() { _ = client.Close() }()
+
+	require.Equal(t, []byte{
+		iac, do, optEcho,
+		iac, do, optSGA,
+		iac, will, optTTYPE,
+		iac, will, optNAWS,
+		iac, sb, optNAWS, 0x03, 0xe8, 0x27, 0x10, iac, se,
+		iac, sb, optTTYPE, 0, 'd', 'u', 'm', 'b', iac, se,
+	}, <-f.negotiation)
+
+	output, err := client.Run(ctx, "show version")
+	require.NoError(t, err)
+	require.Equal(t, "vpp v24.10-release built by root\n", output)
+
+	output, err = client.Run(ctx, "show interface")
+	require.NoError(t, err)
+	require.Equal(t, "local0  0  down\ntap0  1  up\n", output)
+
+	output, err = client.Run(ctx, "show hexdump")
+	require.NoError(t, err)
+	require.Equal(t, "00 ff \xff\n", output)
+
+	output, err = client.Run(ctx, "show history")
+	require.NoError(t, err)
+	require.Equal(t, "vpp# show version\nvpp# show interface\n", output)
+}
+
+func TestClient_Prompt(t *testing.T) {
+	socket, _ := serveFakeCLI(t, "vpp-1# ", map[string]string{"show version": "vpp v24.10\n"})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	client, err := cli.Dial(ctx, socket, cli.WithPrompt("vpp-1# "))
+	require.NoError(t, err)
+	defer func() { _ = client.Close() }()
+
+	output, err := client.Run(ctx, "show version")
+	require.NoError(t, err)
+	require.Equal(t, "vpp v24.10\n", output)
+}
+
+func TestClient_Timeout(t *testing.T) {
+	socket, _ := serveFakeCLI(t, "router> ", nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	_, err := cli.Dial(ctx, socket)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
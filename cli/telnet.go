@@ -0,0 +1,158 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"io"
+
+	"github.com/pkg/errors"
+)
+
+// Telnet commands and options (RFC 854, 857, 858, 1073, 1091) used by the vpp cli
+const (
+	iac  = 255
+	dont = 254
+	do   = 253
+	wont = 252
+	will = 251
+	sb   = 250
+	se   = 240
+
+	optEcho  = 1
+	optSGA   = 3
+	optTTYPE = 24
+	optNAWS  = 31
+
+	ttypeIs   = 0
+	ttypeSend = 1
+)
+
+// Terminal type and window size reported to vpp.  A dumb terminal keeps vpp from sending ANSI escape sequences, a
+// tall window keeps its pager from kicking in.
+const (
+	terminalType   = "dumb"
+	terminalWidth  = 1000
+	terminalHeight = 10000
+)
+
+type telnetState int
+
+const (
+	stateData telnetState = iota
+	stateIAC
+	stateOption
+	stateSB
+	stateSBIAC
+)
+
+// telnet - strips telnet commands from the data received from vpp and answers its negotiation
+type telnet struct {
+	w     io.Writer
+	state telnetState
+	verb  byte
+	sb    []byte
+}
+
+func newTelnet(w io.Writer) *telnet {
+	return &telnet{w: w}
+}
+
+// filter - returns the data in in with all telnet commands removed.  Commands are answered as they are found.
+func (t *telnet) filter(in []byte) ([]byte, error) {
+	data := make([]byte, 0, len(in))
+	for _, b := range in {
+		switch t.state {
+		case stateData:
+			if b == iac {
+				t.state = stateIAC
+				continue
+			}
+			data = append(data, b)
+		case stateIAC:
+			switch b {
+			case iac:
+				data = append(data, iac)
+				t.state = stateData
+			case do, dont, will, wont:
+				t.verb = b
+				t.state = stateOption
+			case sb:
+				t.sb = t.sb[:0]
+				t.state = stateSB
+			default:
+				t.state = stateData
+			}
+		case stateOption:
+			t.state = stateData
+			if err := t.negotiate(t.verb, b); err != nil {
+				return nil, err
+			}
+		case stateSB:
+			if b == iac {
+				t.state = stateSBIAC
+				continue
+			}
+			t.sb = append(t.sb, b)
+		case stateSBIAC:
+			if b == se {
+				t.state = stateData
+				if err := t.subnegotiate(t.sb); err != nil {
+					return nil, err
+				}
+				continue
+			}
+			t.sb = append(t.sb, b)
+			t.state = stateSB
+		}
+	}
+	return data, nil
+}
+
+// negotiate - lets vpp echo and suppress go-ahead, reports the terminal type and window size and refuses
+// everything else
+func (t *telnet) negotiate(verb, option byte) error {
+	switch verb {
+	case will:
+		if option == optEcho || option == optSGA {
+			return t.send(iac, do, option)
+		}
+		return t.send(iac, dont, option)
+	case do:
+		switch option {
+		case optTTYPE:
+			return t.send(iac, will, option)
+		case optNAWS:
+			return t.send(iac, will, optNAWS, iac, sb, optNAWS,
+				terminalWidth>>8, terminalWidth&0xff, terminalHeight>>8, terminalHeight&0xff, iac, se)
+		}
+		return t.send(iac, wont, option)
+	}
+	return nil
+}
+
+func (t *telnet) subnegotiate(params []byte) error {
+	if len(params) == 2 && params[0] == optTTYPE && params[1] == ttypeSend {
+		msg := append([]byte{iac, sb, optTTYPE, ttypeIs}, terminalType...)
+		return t.send(append(msg, iac, se)...)
+	}
+	return nil
+}
+
+func (t *telnet) send(msg ...byte) error {
+	_, err := t.w.Write(msg)
+	return errors.Wrap(err, "unable to answer telnet negotiation")
+}
@@ -0,0 +1,101 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vpphelper_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.fd.io/govpp/adapter/mock"
+	"go.fd.io/govpp/binapi/vlib"
+	"go.fd.io/govpp/codec"
+
+	"github.com/networkservicemesh/vpphelper"
+)
+
+// mockCLI - makes vppAdapter answer cli_inband requests with the output of the command in outputs
+func mockCLI(vppAdapter *mock.VppAdapter, outputs map[string]string) {
+	vppAdapter.MockReplyHandler(func(msg mock.MessageDTO) ([]byte, uint16, bool) {
+		request := &vlib.CliInband{}
+		if requestID, _ := vppAdapter.GetMsgID(request.GetMessageName(), request.GetCrcString()); msg.MsgID != requestID {
+			return nil, 0, false
+		}
+		if err := codec.DefaultCodec.DecodeMsg(msg.Data, request); err != nil {
+			return nil, 0, false
+		}
+		reply := &vlib.CliInbandReply{Reply: outputs[request.Cmd]}
+		msgID, _ := vppAdapter.GetMsgID(reply.GetMessageName(), reply.GetCrcString())
+		data, err := vppAdapter.ReplyBytes(msg, reply)
+		return data, msgID, err == nil
+	})
+}
+
+func dialCLI(t *testing.T, outputs map[string]string) (context.Context, vpphelper.Connection) {
+	vppAdapter, _ := mockVPP(t)
+	mockCLI(vppAdapter, outputs)
+	socket := filepath.Join(t.TempDir(), "api.sock")
+	require.NoError(t, os.WriteFile(socket, nil, 0o600))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+	return ctx, vpphelper.DialContext(ctx, socket)
+}
+
+func TestRunCLI(t *testing.T) {
+	ctx, conn := dialCLI(t, map[string]string{
+		"show version":                "vpp v24.10-release built by root on localhost at 2024-10-30T12:00:00\n",
+		"show errors":                 "   Count                  Node                              Reason               Severity \n",
+		"show foo":                    "show: unknown input `foo'\n",
+		"bogus":                       "unknown input `bogus'\n",
+		"set interface state tap0 up": "set interface state: unknown interface `tap0'\n",
+	})
+
+	for _, cmd := range []string{"show version", "show errors"} {
+		output, err := vpphelper.RunCLI(ctx, conn, cmd)
+		require.NoError(t, err, cmd)
+		require.NotEmpty(t, output)
+	}
+	for _, cmd := range []string{"show foo", "bogus", "set interface state tap0 up"} {
+		output, err := vpphelper.RunCLI(ctx, conn, cmd)
+		var cliErr *vpphelper.CLIError
+		require.ErrorAs(t, err, &cliErr, cmd)
+		require.Equal(t, cmd, cliErr.Command)
+		require.Equal(t, output, cliErr.Output)
+	}
+}
+
+func TestRunCLIBatch(t *testing.T) {
+	ctx, conn := dialCLI(t, map[string]string{
+		"show version":  "vpp v24.10\n",
+		"show foo":      "show: unknown input `foo'\n",
+		"show hardware": "local0\n",
+	})
+
+	outputs, err := vpphelper.RunCLIBatch(ctx, conn, "show version", "show hardware")
+	require.NoError(t, err)
+	require.Equal(t, []string{"vpp v24.10\n", "local0\n"}, outputs)
+
+	outputs, err = vpphelper.RunCLIBatch(ctx, conn, "show version", "show foo", "show hardware")
+	var cliErr *vpphelper.CLIError
+	require.ErrorAs(t, err, &cliErr)
+	require.Equal(t, "show foo", cliErr.Command)
+	require.Equal(t, []string{"vpp v24.10\n", "show: unknown input `foo'\n"}, outputs)
+}
@@ -0,0 +1,111 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vpphelper
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/edwarnicke/log"
+	"github.com/pkg/errors"
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// ConfigPolicy - what is done with a config file (vpp.conf) that already exists in the root dir
+type ConfigPolicy int
+
+const (
+	// KeepExisting - the existing file is used as is, even if it differs from the rendered template
+	KeepExisting ConfigPolicy = iota
+	// Overwrite - the existing file is replaced with the rendered template
+	Overwrite
+	// FailOnDrift - StartContext fails with a *ConfigDriftError if the existing file differs from the rendered template
+	FailOnDrift
+)
+
+func (p ConfigPolicy) String() string {
+	switch p {
+	case KeepExisting:
+		return "KeepExisting"
+	case Overwrite:
+		return "Overwrite"
+	case FailOnDrift:
+		return "FailOnDrift"
+	}
+	return fmt.Sprintf("ConfigPolicy(%d)", int(p))
+}
+
+// WithConfigPolicy - sets what is done with config files that already exist in the root dir, KeepExisting by default
+func WithConfigPolicy(policy ConfigPolicy) Option {
+	return func(opt *option) {
+		opt.configPolicy = policy
+	}
+}
+
+// ConfigDriftError - returned by StartContext with FailOnDrift if a config file differs from the rendered template
+type ConfigDriftError struct {
+	Filename string
+	// Diff - unified diff from the file to the rendered template
+	Diff string
+}
+
+func (e *ConfigDriftError) Error() string {
+	return fmt.Sprintf("config file %s differs from the rendered template:\n%s", e.Filename, e.Diff)
+}
+
+// writeConfigFile - writes contents to filename if it does not exist, otherwise applies policy.  The policy and
+// the hash of the file vpp is going to use are logged.
+func writeConfigFile(ctx context.Context, policy ConfigPolicy, filename, contents string) error {
+	existing, err := os.ReadFile(filepath.Clean(filename))
+	write := true
+	switch {
+	case os.IsNotExist(err):
+		log.Entry(ctx).Infof("Configuration file: %q not found, using defaults", filename)
+	case err != nil:
+		return errors.WithStack(err)
+	case string(existing) == contents:
+		write = false
+	case policy == KeepExisting:
+		log.Entry(ctx).Warnf("Configuration file: %q differs from the rendered template, keeping it", filename)
+		contents, write = string(existing), false
+	case policy == FailOnDrift:
+		diff, diffErr := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+			A:        difflib.SplitLines(string(existing)),
+			B:        difflib.SplitLines(contents),
+			FromFile: filename,
+			ToFile:   "rendered template",
+			Context:  3,
+		})
+		if diffErr != nil {
+			return errors.WithStack(diffErr)
+		}
+		return &ConfigDriftError{Filename: filename, Diff: diff}
+	default:
+		log.Entry(ctx).Infof("Configuration file: %q differs from the rendered template, overwriting it", filename)
+	}
+	log.Entry(ctx).Infof("Configuration file: %q policy %s sha256 %x", filename, policy, sha256.Sum256([]byte(contents)))
+	if !write {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(filename), 0o700); err != nil {
+		return errors.WithStack(err)
+	}
+	return errors.WithStack(os.WriteFile(filename, []byte(contents), 0o600))
+}
@@ -0,0 +1,89 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vpphelper_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/vpphelper"
+)
+
+const staleVPPConf = "unix {\n  nodaemon\n  cli-listen /run/vpp/cli.sock\n}\n"
+
+// startWithStaleConfig - starts vpp with policy in a root dir that has a stale vpp.conf and returns the root dir
+func startWithStaleConfig(t *testing.T, policy vpphelper.ConfigPolicy) (rootDir string, err error) {
+	_, _ = mockVPP(t)
+	fakeVPP(t, "exec sleep 60")
+	rootDir = t.TempDir()
+	configFile := filepath.Join(rootDir, "/etc/vpp/helper/vpp.conf")
+	require.NoError(t, os.MkdirAll(filepath.Dir(configFile), 0o700))
+	require.NoError(t, os.WriteFile(configFile, []byte(staleVPPConf), 0o600))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	instance, err := vpphelper.StartContext(ctx, vpphelper.WithRootDir(rootDir), vpphelper.WithConfigPolicy(policy))
+	if err == nil {
+		require.NoError(t, instance.Stop(ctx))
+	}
+	return rootDir, err
+}
+
+func TestWithConfigPolicy_KeepExisting(t *testing.T) {
+	rootDir, err := startWithStaleConfig(t, vpphelper.KeepExisting)
+	require.NoError(t, err)
+	require.Equal(t, staleVPPConf, readFile(t, filepath.Join(rootDir, "/etc/vpp/helper/vpp.conf")))
+}
+
+func TestWithConfigPolicy_Overwrite(t *testing.T) {
+	rootDir, err := startWithStaleConfig(t, vpphelper.Overwrite)
+	require.NoError(t, err)
+	config := readFile(t, filepath.Join(rootDir, "/etc/vpp/helper/vpp.conf"))
+	require.NotContains(t, config, "cli-listen /run/vpp/cli.sock")
+	require.Contains(t, config, "cli-listen "+rootDir+"/var/run/vpp/cli.sock")
+}
+
+func TestWithConfigPolicy_FailOnDrift(t *testing.T) {
+	rootDir, err := startWithStaleConfig(t, vpphelper.FailOnDrift)
+	configFile := filepath.Join(rootDir, "/etc/vpp/helper/vpp.conf")
+	var driftErr *vpphelper.ConfigDriftError
+	require.ErrorAs(t, err, &driftErr)
+	require.Equal(t, configFile, driftErr.Filename)
+	require.Contains(t, driftErr.Diff, "--- "+configFile+"\n+++ rendered template\n")
+	require.Contains(t, driftErr.Diff, "\n-  cli-listen /run/vpp/cli.sock\n")
+	require.Contains(t, driftErr.Diff, "\n+  cli-listen "+rootDir+"/var/run/vpp/cli.sock\n")
+	require.Equal(t, staleVPPConf, readFile(t, configFile))
+}
+
+func TestWithConfigPolicy_FailOnDrift_NoDrift(t *testing.T) {
+	_, _ = mockVPP(t)
+	fakeVPP(t, "exec sleep 60")
+	rootDir := t.TempDir()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	for range 2 {
+		instance, err := vpphelper.StartContext(ctx, vpphelper.WithRootDir(rootDir), vpphelper.WithConfigPolicy(vpphelper.FailOnDrift))
+		require.NoError(t, err)
+		require.NoError(t, instance.Stop(ctx))
+	}
+}
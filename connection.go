@@ -1,4 +1,5 @@
 // Copyright (c) 2020-2024 Cisco and/or its affiliates.
+// Copyright (c) 2026 OpenInfra Foundation Europe.
 //
 // SPDX-License-Identifier: Apache-2.0
 //
@@ -18,89 +19,407 @@ package vpphelper
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"path/filepath"
+	"sync"
 	"time"
 
 	"github.com/edwarnicke/log"
 	"github.com/pkg/errors"
 	"go.fd.io/govpp"
 	"go.fd.io/govpp/api"
+	"go.fd.io/govpp/binapi/memclnt"
 	"go.fd.io/govpp/core"
 	"gopkg.in/fsnotify.v1"
 )
 
+// Connection - api.Connection to vpp that transparently reconnects whenever vpp restarts
+type Connection interface {
+	api.Connection
+	// Disconnect - disconnects from vpp and stops any pending dial or reconnect.  It is safe to call in any state.
+	// Calls waiting for the connection and calls made afterwards fail with ErrClosed.
+	Disconnect()
+	// Reconnected - returns a channel that is closed the next time the connection to vpp is re-established
+	Reconnected() <-chan struct{}
+	// State - returns the current state of the connection
+	State() ConnectionState
+	// Ready - returns a channel that is closed once the connection is Connected or Failed.
+	// After a disconnect Ready returns a new channel that is closed once the connection is re-established.
+	Ready() <-chan struct{}
+	// Err - returns the error the connection Failed with, nil otherwise
+	Err() error
+	// Subscribe - returns a channel that receives the current state followed by every state transition.
+	// The channel is closed when ctx is done, the connection Failed or was disconnected.  Events are dropped for subscribers
+	// that do not keep up.
+	Subscribe(ctx context.Context) <-chan ConnectionEvent
+}
+
+// ErrClosed - returned by calls on a Connection that was disconnected with Disconnect()
+var ErrClosed = errors.New("vpp connection is closed")
+
+// DialAttempt - a failed attempt to connect to vpp
+type DialAttempt struct {
+	Time time.Time
+	Err  error
+}
+
+// DialError - error returned once DialContext gives up connecting to vpp because a limit set with a DialOption was reached
+type DialError struct {
+	Filename string
+	// Attempts - all failed connection attempts, oldest first
+	Attempts []DialAttempt
+	// Err - the reason for giving up
+	Err error
+}
+
+func (e *DialError) Error() string {
+	return fmt.Sprintf("unable to connect to %s after %d attempts: %v", e.Filename, len(e.Attempts), e.Err)
+}
+
+func (e *DialError) Unwrap() error {
+	return e.Err
+}
+
 type connection struct {
-	*core.Connection
-	ready chan struct{}
-	err   error
+	filename  string
+	opts      *dialOption
+	cancel    context.CancelFunc
+	closeOnce sync.Once
+	closed    chan struct{}
+	done      chan struct{}
+
+	mu          sync.RWMutex
+	conn        *core.Connection
+	ready       chan struct{}
+	err         error
+	reconnected chan struct{}
+	state       ConnectionState
+	since       time.Time
+	subscribers map[chan ConnectionEvent]struct{}
 }
 
 // DialContext - Dials vpp and returns a Connection
 // DialContext is 'lazy' meaning that if there is no socket yet at filename, we will continue to try
 // until there is one or the ctx is canceled.
-func DialContext(ctx context.Context, filename string) api.Connection {
+// If the socket at filename is removed or replaced (for example because vpp was restarted), the Connection
+// waits for the new socket and reconnects.  Calls made while reconnecting block until the connection is
+// re-established or their ctx is canceled.
+// The interval between connection attempts and the limits after which DialContext gives up with a *DialError
+// can be set with DialOptions.
+func DialContext(ctx context.Context, filename string, opts ...DialOption) Connection {
+	ctx, cancel := context.WithCancel(ctx)
 	c := &connection{
-		ready: make(chan struct{}),
+		filename:    filename,
+		opts:        newDialOption(opts...),
+		cancel:      cancel,
+		closed:      make(chan struct{}),
+		done:        make(chan struct{}),
+		ready:       make(chan struct{}),
+		reconnected: make(chan struct{}),
+		state:       Connecting,
+		since:       time.Now(),
+		subscribers: make(map[chan ConnectionEvent]struct{}),
 	}
-	go c.connect(ctx, filename)
+	go c.run(ctx)
 	return c
 }
 
-func (c *connection) connect(ctx context.Context, filename string) {
-	defer close(c.ready)
+func (c *connection) run(ctx context.Context) {
+	defer close(c.done)
+	for reconnect := false; ; reconnect = true {
+		conn, info, err := c.connect(ctx)
+		c.setConnection(conn, err, reconnect)
+		if err != nil {
+			return
+		}
+		err = c.watch(ctx, conn, info)
+		c.resetConnection()
+		conn.Disconnect()
+		if err != nil {
+			log.Entry(ctx).Debugf("stopped watching %s due to %+v", c.filename, err)
+			c.setConnection(nil, err, reconnect)
+			return
+		}
+		log.Entry(ctx).Warnf("lost connection to %s, reconnecting", c.filename)
+	}
+}
+
+// watch - waits until conn has to be re-established because the socket was removed or replaced or because vpp
+// stopped answering keepalives.
+// A non-nil error is returned only if it is no longer possible to watch the connection.
+func (c *connection) watch(ctx context.Context, conn *core.Connection, info os.FileInfo) error {
+	if c.opts.keepaliveInterval <= 0 {
+		return waitForDisconnect(ctx, c.filename, info)
+	}
+
+	watchCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- waitForDisconnect(watchCtx, c.filename, info)
+	}()
+
+	ticker := time.NewTicker(c.opts.keepaliveInterval)
+	defer ticker.Stop()
+	missed := 0
+	for {
+		select {
+		case err := <-errCh:
+			return err
+		case <-ticker.C:
+			pingCtx, pingCancel := context.WithTimeout(watchCtx, c.opts.keepaliveTimeout)
+			err := conn.Invoke(pingCtx, &memclnt.ControlPing{}, &memclnt.ControlPingReply{})
+			pingCancel()
+			switch {
+			case err == nil:
+				if missed > 0 {
+					log.Entry(ctx).Infof("%s answered keepalive after %d missed", c.filename, missed)
+				}
+				missed = 0
+			case watchCtx.Err() != nil:
+			default:
+				missed++
+				log.Entry(ctx).Warnf("%s missed keepalive %d/%d due to %v", c.filename, missed, c.opts.keepaliveMaxMissed, err)
+				if missed >= c.opts.keepaliveMaxMissed {
+					c.mu.Lock()
+					c.setState(Unhealthy, err)
+					c.mu.Unlock()
+					cancel()
+					<-errCh
+					return nil
+				}
+			}
+		}
+	}
+}
+
+func (c *connection) connect(ctx context.Context) (conn *core.Connection, info os.FileInfo, err error) {
+	err = dial(ctx, c.filename, c.opts, func(dialCtx context.Context) error {
+		if info, err = os.Stat(c.filename); err != nil {
+			return errors.WithStack(err)
+		}
+		if conn, err = govpp.Connect(c.filename); err != nil {
+			return err
+		}
+		if err = runReadinessChecks(dialCtx, conn, c.opts.readinessChecks); err != nil {
+			conn.Disconnect()
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return conn, info, nil
+}
+
+// dial - waits for the socket at filename to be created and calls connect until it succeeds, backing off between
+// attempts and giving up with a *DialError once a limit set in o is reached.
+func dial(ctx context.Context, filename string, o *dialOption, connect func(dialCtx context.Context) error) error {
+	dialCtx := ctx
+	if o.timeout > 0 {
+		var cancel context.CancelFunc
+		dialCtx, cancel = context.WithTimeout(ctx, o.timeout)
+		defer cancel()
+	}
+
 	now := time.Now()
-	c.err = waitForSocket(ctx, filename)
-	if c.err != nil {
-		log.Entry(ctx).Debugf("%s was not created after %s due to %+v", filename, time.Since(now), c.err)
-		return
+	if err := waitForSocket(dialCtx, filename); err != nil {
+		log.Entry(ctx).Debugf("%s was not created after %s due to %+v", filename, time.Since(now), err)
+		if ctx.Err() == nil && dialCtx.Err() != nil {
+			return &DialError{Filename: filename, Err: dialCtx.Err()}
+		}
+		return err
 	}
 	log.Entry(ctx).Debugf("%s was created after %s", filename, time.Since(now))
 	now = time.Now()
-	attempts := 1
+	var attempts []DialAttempt
 	for {
+		err := connect(dialCtx)
+		if err == nil {
+			log.Entry(ctx).Debugf("successfully connected to %s after %s and %d attempts", filename, time.Since(now), len(attempts)+1)
+			return nil
+		}
+		attempts = append(attempts, DialAttempt{Time: time.Now(), Err: err})
+		if o.maxAttempts > 0 && len(attempts) >= o.maxAttempts {
+			err = &DialError{Filename: filename, Attempts: attempts, Err: err}
+			log.Entry(ctx).Debugf("unable to connect to %s after %s due to %+v", filename, time.Since(now), err)
+			return err
+		}
+		interval := o.interval(len(attempts))
+		log.Entry(ctx).Debugf("attempt %d to connect to %s failed due to %v, retrying in %s", len(attempts), filename, err, interval)
+
+		timer := time.NewTimer(interval)
 		select {
-		case <-ctx.Done():
-			c.err = errors.WithStack(ctx.Err())
-			log.Entry(ctx).Debugf("unable to connect to %s after %s due to %+v", filename, time.Since(now), c.err)
-			return
-		default:
-			c.Connection, c.err = govpp.Connect(filename)
-			if c.err == nil {
-				log.Entry(ctx).Debugf("successfully connected to %s after %s and %d attempts", filename, time.Since(now), attempts)
-				return
+		case <-dialCtx.Done():
+			timer.Stop()
+			err = errors.WithStack(ctx.Err())
+			if ctx.Err() == nil {
+				err = &DialError{Filename: filename, Attempts: attempts, Err: dialCtx.Err()}
 			}
-			attempts++
-			<-time.After(time.Millisecond)
+			log.Entry(ctx).Debugf("unable to connect to %s after %s due to %+v", filename, time.Since(now), err)
+			return err
+		case <-timer.C:
 		}
 	}
 }
 
-func (c *connection) NewStream(ctx context.Context, options ...api.StreamOption) (api.Stream, error) {
+func (c *connection) setConnection(conn *core.Connection, err error, reconnect bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	select {
-	case <-ctx.Done():
-		return nil, ctx.Err()
-	case <-c.ready:
-		if c.err != nil {
-			return nil, c.err
+	case <-c.closed:
+		if err != nil {
+			err = ErrClosed
 		}
+	default:
+	}
+	c.conn = conn
+	c.err = err
+	close(c.ready)
+	if err != nil {
+		c.setState(Failed, err)
+		return
+	}
+	if reconnect {
+		close(c.reconnected)
+		c.reconnected = make(chan struct{})
 	}
-	return c.Connection.NewStream(ctx, options...)
+	c.setState(Connected, nil)
 }
 
-func (c *connection) Invoke(ctx context.Context, req, reply api.Message) error {
-	select {
-	case <-ctx.Done():
-		return ctx.Err()
-	case <-c.ready:
-		if c.err != nil {
-			return c.err
+func (c *connection) resetConnection() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.conn = nil
+	c.ready = make(chan struct{})
+	c.setState(Disconnected, nil)
+}
+
+// setState - must be called with c.mu locked
+func (c *connection) setState(state ConnectionState, err error) {
+	c.state = state
+	c.since = time.Now()
+	event := ConnectionEvent{Timestamp: c.since, State: state, Error: err}
+	for ch := range c.subscribers {
+		select {
+		case ch <- event:
+		default:
+		}
+		if state == Failed {
+			delete(c.subscribers, ch)
+			close(ch)
 		}
 	}
-	return c.Connection.Invoke(ctx, req, reply)
 }
 
-var _ api.Connection = &connection{}
+// current - waits until there is a connection to vpp (or the dial has failed) and returns it
+func (c *connection) current(ctx context.Context) (*core.Connection, error) {
+	for {
+		c.mu.RLock()
+		ready := c.ready
+		c.mu.RUnlock()
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ready:
+		}
+
+		c.mu.RLock()
+		conn, err := c.conn, c.err
+		c.mu.RUnlock()
+		if conn != nil || err != nil {
+			return conn, err
+		}
+	}
+}
+
+func (c *connection) NewStream(ctx context.Context, options ...api.StreamOption) (api.Stream, error) {
+	conn, err := c.current(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return conn.NewStream(ctx, options...)
+}
+
+func (c *connection) Invoke(ctx context.Context, req, reply api.Message) error {
+	conn, err := c.current(ctx)
+	if err != nil {
+		return err
+	}
+	return conn.Invoke(ctx, req, reply)
+}
+
+// WatchEvent - watches events on the current connection to vpp.  The watcher is closed when the connection is lost.
+func (c *connection) WatchEvent(ctx context.Context, event api.Message) (api.Watcher, error) {
+	conn, err := c.current(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return conn.WatchEvent(ctx, event)
+}
+
+func (c *connection) Disconnect() {
+	c.closeOnce.Do(func() {
+		close(c.closed)
+		c.cancel()
+	})
+	<-c.done
+}
+
+func (c *connection) Reconnected() <-chan struct{} {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.reconnected
+}
+
+func (c *connection) State() ConnectionState {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.state
+}
+
+func (c *connection) Ready() <-chan struct{} {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.ready
+}
+
+func (c *connection) Err() error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.err
+}
+
+func (c *connection) Subscribe(ctx context.Context) <-chan ConnectionEvent {
+	ch := make(chan ConnectionEvent, subscriberBufSize)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ch <- ConnectionEvent{Timestamp: c.since, State: c.state, Error: c.err}
+	if c.state == Failed {
+		close(ch)
+		return ch
+	}
+	c.subscribers[ch] = struct{}{}
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-c.done:
+		}
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		if _, ok := c.subscribers[ch]; ok {
+			delete(c.subscribers, ch)
+			close(ch)
+		}
+	}()
+	return ch
+}
+
+var _ Connection = &connection{}
 
 func waitForSocket(ctx context.Context, filename string) error {
 	watcher, err := fsnotify.NewWatcher()
@@ -134,3 +453,33 @@ func waitForSocket(ctx context.Context, filename string) error {
 	}
 	return nil
 }
+
+// waitForDisconnect - waits until the socket at filename described by info is removed or replaced.
+// A non-nil error is returned only if it is no longer possible to watch the socket.
+func waitForDisconnect(ctx context.Context, filename string, info os.FileInfo) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer func() { _ = watcher.Close() }()
+	if err = watcher.Add(filepath.Dir(filename)); err != nil {
+		return errors.WithStack(err)
+	}
+
+	// The socket may have been replaced before we started watching it
+	if current, statErr := os.Stat(filename); statErr != nil || !os.SameFile(info, current) {
+		return nil
+	}
+	for {
+		select {
+		case event := <-watcher.Events:
+			if event.Name == filename && event.Op&(fsnotify.Create|fsnotify.Remove) != 0 {
+				return nil
+			}
+		case err = <-watcher.Errors:
+			return errors.WithStack(err)
+		case <-ctx.Done():
+			return errors.WithStack(ctx.Err())
+		}
+	}
+}
@@ -0,0 +1,306 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vpphelper_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"go.fd.io/govpp"
+	"go.fd.io/govpp/adapter"
+	"go.fd.io/govpp/adapter/mock"
+	"go.fd.io/govpp/api"
+	"go.fd.io/govpp/binapi/memclnt"
+	"go.uber.org/goleak"
+
+	"github.com/networkservicemesh/vpphelper"
+)
+
+// mockVPP - replaces the govpp adapter with a mock and returns it along with the number of times it was connected
+func mockVPP(t *testing.T) (*mock.VppAdapter, *int32) {
+	var connects int32
+	vppAdapter := mock.NewVppAdapter()
+	vppAdapter.SetConnectCallback(func() { atomic.AddInt32(&connects, 1) })
+	mockReply(vppAdapter, &memclnt.ControlPing{}, &memclnt.ControlPingReply{})
+
+	newVppAdapter := govpp.NewVppAdapter
+	govpp.NewVppAdapter = func(string) adapter.VppAPI { return vppAdapter }
+	t.Cleanup(func() { govpp.NewVppAdapter = newVppAdapter })
+	return vppAdapter, &connects
+}
+
+// mockReply - makes vppAdapter answer every request with reply
+func mockReply(vppAdapter *mock.VppAdapter, request, reply api.Message) {
+	vppAdapter.MockReplyHandler(func(msg mock.MessageDTO) ([]byte, uint16, bool) {
+		if requestID, _ := vppAdapter.GetMsgID(request.GetMessageName(), request.GetCrcString()); msg.MsgID != requestID {
+			return nil, 0, false
+		}
+		msgID, _ := vppAdapter.GetMsgID(reply.GetMessageName(), reply.GetCrcString())
+		data, err := vppAdapter.ReplyBytes(msg, reply)
+		return data, msgID, err == nil
+	})
+}
+
+func TestDialContext_Lazy(t *testing.T) {
+	_, connects := mockVPP(t)
+	socket := filepath.Join(t.TempDir(), "api.sock")
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	conn := vpphelper.DialContext(ctx, socket)
+
+	require.NoError(t, os.WriteFile(socket, nil, 0o600))
+	require.NoError(t, conn.Invoke(ctx, &memclnt.ControlPing{}, &memclnt.ControlPingReply{}))
+	require.Equal(t, int32(1), atomic.LoadInt32(connects))
+}
+
+func TestDialContext_Reconnect(t *testing.T) {
+	_, connects := mockVPP(t)
+	socket := filepath.Join(t.TempDir(), "api.sock")
+	require.NoError(t, os.WriteFile(socket, nil, 0o600))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	conn := vpphelper.DialContext(ctx, socket)
+	require.NoError(t, conn.Invoke(ctx, &memclnt.ControlPing{}, &memclnt.ControlPingReply{}))
+
+	reconnected := conn.Reconnected()
+	require.NoError(t, os.Remove(socket))
+	require.NoError(t, os.WriteFile(socket, nil, 0o600))
+
+	select {
+	case <-reconnected:
+	case <-ctx.Done():
+		require.FailNow(t, "connection was not re-established")
+	}
+	require.NoError(t, conn.Invoke(ctx, &memclnt.ControlPing{}, &memclnt.ControlPingReply{}))
+	require.Equal(t, int32(2), atomic.LoadInt32(connects))
+}
+
+func TestDialContext_Canceled(t *testing.T) {
+	_, _ = mockVPP(t)
+	socket := filepath.Join(t.TempDir(), "api.sock")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	conn := vpphelper.DialContext(ctx, socket)
+	cancel()
+
+	err := conn.Invoke(context.Background(), &memclnt.ControlPing{}, &memclnt.ControlPingReply{})
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestDialContext_MaxAttempts(t *testing.T) {
+	vppAdapter, connects := mockVPP(t)
+	vppAdapter.MockConnectError(errors.New("connection refused"))
+	socket := filepath.Join(t.TempDir(), "api.sock")
+	require.NoError(t, os.WriteFile(socket, nil, 0o600))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	conn := vpphelper.DialContext(ctx, socket,
+		vpphelper.WithBackoff(time.Millisecond, 4*time.Millisecond),
+		vpphelper.WithMaxAttempts(3),
+	)
+
+	err := conn.Invoke(ctx, &memclnt.ControlPing{}, &memclnt.ControlPingReply{})
+	var dialErr *vpphelper.DialError
+	require.ErrorAs(t, err, &dialErr)
+	require.Len(t, dialErr.Attempts, 3)
+	require.EqualError(t, dialErr.Attempts[2].Err, "connection refused")
+	require.Equal(t, int32(3), atomic.LoadInt32(connects))
+}
+
+func TestDialContext_BackoffDefaults(t *testing.T) {
+	vppAdapter, connects := mockVPP(t)
+	vppAdapter.MockConnectError(errors.New("connection refused"))
+	socket := filepath.Join(t.TempDir(), "api.sock")
+	require.NoError(t, os.WriteFile(socket, nil, 0o600))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	// an interval of 0 is replaced by DefaultInitialInterval and the jitter is clamped to 1, so that the attempts
+	// do not spin
+	conn := vpphelper.DialContext(ctx, socket,
+		vpphelper.WithBackoff(0, 0),
+		vpphelper.WithJitter(2),
+		vpphelper.WithDialTimeout(100*time.Millisecond),
+	)
+
+	var dialErr *vpphelper.DialError
+	require.ErrorAs(t, conn.Invoke(ctx, &memclnt.ControlPing{}, &memclnt.ControlPingReply{}), &dialErr)
+	require.Less(t, atomic.LoadInt32(connects), int32(50))
+}
+
+func TestDialContext_DialTimeout(t *testing.T) {
+	_, _ = mockVPP(t)
+	socket := filepath.Join(t.TempDir(), "api.sock")
+
+	conn := vpphelper.DialContext(context.Background(), socket, vpphelper.WithDialTimeout(10*time.Millisecond))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	err := conn.Invoke(ctx, &memclnt.ControlPing{}, &memclnt.ControlPingReply{})
+	var dialErr *vpphelper.DialError
+	require.ErrorAs(t, err, &dialErr)
+	require.Empty(t, dialErr.Attempts)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestDialContext_State(t *testing.T) {
+	_, _ = mockVPP(t)
+	socket := filepath.Join(t.TempDir(), "api.sock")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	conn := vpphelper.DialContext(ctx, socket)
+	subscribeCtx, subscribeCancel := context.WithCancel(context.Background())
+	defer subscribeCancel()
+	events := conn.Subscribe(subscribeCtx)
+	require.Equal(t, vpphelper.Connecting, conn.State())
+
+	expectState := func(expected vpphelper.ConnectionState) {
+		select {
+		case event := <-events:
+			require.Equal(t, expected, event.State)
+		case <-time.After(time.Second):
+			require.FailNowf(t, "no state transition", "expected %s", expected)
+		}
+	}
+	expectState(vpphelper.Connecting)
+
+	require.NoError(t, os.WriteFile(socket, nil, 0o600))
+	expectState(vpphelper.Connected)
+	<-conn.Ready()
+	require.NoError(t, conn.Err())
+
+	require.NoError(t, os.Remove(socket))
+	expectState(vpphelper.Disconnected)
+	require.NoError(t, os.WriteFile(socket, nil, 0o600))
+	expectState(vpphelper.Connected)
+
+	cancel()
+	expectState(vpphelper.Disconnected)
+	expectState(vpphelper.Failed)
+	_, ok := <-events
+	require.False(t, ok)
+	require.ErrorIs(t, conn.Err(), context.Canceled)
+	require.Equal(t, vpphelper.Failed, conn.State())
+}
+
+func TestDialContext_Disconnect(t *testing.T) {
+	t.Cleanup(func() {
+		goleak.VerifyNone(t)
+	})
+	_, _ = mockVPP(t)
+	socket := filepath.Join(t.TempDir(), "api.sock")
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	conn := vpphelper.DialContext(ctx, socket)
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- conn.Invoke(ctx, &memclnt.ControlPing{}, &memclnt.ControlPingReply{})
+	}()
+	conn.Disconnect()
+	require.ErrorIs(t, <-errCh, vpphelper.ErrClosed)
+	require.ErrorIs(t, conn.Err(), vpphelper.ErrClosed)
+
+	// Disconnect is idempotent
+	conn.Disconnect()
+	require.ErrorIs(t, conn.Invoke(ctx, &memclnt.ControlPing{}, &memclnt.ControlPingReply{}), vpphelper.ErrClosed)
+}
+
+func TestDialContext_DisconnectConnected(t *testing.T) {
+	t.Cleanup(func() {
+		goleak.VerifyNone(t)
+	})
+	_, _ = mockVPP(t)
+	socket := filepath.Join(t.TempDir(), "api.sock")
+	require.NoError(t, os.WriteFile(socket, nil, 0o600))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	conn := vpphelper.DialContext(ctx, socket)
+	require.NoError(t, conn.Invoke(ctx, &memclnt.ControlPing{}, &memclnt.ControlPingReply{}))
+	// a subscription with a ctx that is never done ends with the connection
+	events := conn.Subscribe(context.Background())
+
+	conn.Disconnect()
+	require.Equal(t, vpphelper.Failed, conn.State())
+	for range events {
+	}
+	require.ErrorIs(t, conn.Invoke(ctx, &memclnt.ControlPing{}, &memclnt.ControlPingReply{}), vpphelper.ErrClosed)
+}
+
+func TestDialContext_Keepalive(t *testing.T) {
+	vppAdapter, connects := mockVPP(t)
+	socket := filepath.Join(t.TempDir(), "api.sock")
+	require.NoError(t, os.WriteFile(socket, nil, 0o600))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	conn := vpphelper.DialContext(ctx, socket, vpphelper.WithKeepalive(5*time.Millisecond, 5*time.Millisecond, 2))
+	events := conn.Subscribe(ctx)
+	require.NoError(t, conn.Invoke(ctx, &memclnt.ControlPing{}, &memclnt.ControlPingReply{}))
+
+	// vpp stops answering control_ping
+	vppAdapter.MockClearReplyHandlers()
+
+	var states []vpphelper.ConnectionState
+	for event := range events {
+		states = append(states, event.State)
+		if event.State == vpphelper.Unhealthy {
+			require.Error(t, event.Error)
+			break
+		}
+	}
+	require.Equal(t, []vpphelper.ConnectionState{vpphelper.Connected, vpphelper.Unhealthy}, states[len(states)-2:])
+	require.Equal(t, vpphelper.Disconnected, (<-events).State)
+	require.Equal(t, vpphelper.Connected, (<-events).State)
+	require.GreaterOrEqual(t, atomic.LoadInt32(connects), int32(2))
+}
+
+func TestDialContext_KeepaliveDefaults(t *testing.T) {
+	_, _ = mockVPP(t)
+	socket := filepath.Join(t.TempDir(), "api.sock")
+	require.NoError(t, os.WriteFile(socket, nil, 0o600))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	// a timeout and maxMissed of 0 are replaced by the interval and DefaultKeepaliveMaxMissed
+	conn := vpphelper.DialContext(ctx, socket, vpphelper.WithKeepalive(5*time.Millisecond, 0, 0))
+	events := conn.Subscribe(ctx)
+	require.NoError(t, conn.Invoke(ctx, &memclnt.ControlPing{}, &memclnt.ControlPingReply{}))
+
+	timeout := time.After(100 * time.Millisecond)
+	for {
+		select {
+		case event := <-events:
+			require.NotEqual(t, vpphelper.Unhealthy, event.State)
+		case <-timeout:
+			require.Equal(t, vpphelper.Connected, conn.State())
+			return
+		}
+	}
+}
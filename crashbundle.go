@@ -0,0 +1,302 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vpphelper
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/edwarnicke/log"
+	"github.com/pkg/errors"
+)
+
+const (
+	crashBundleLines     = 200
+	crashBundleLogWindow = 256 * 1024
+	// apiPostMortemFormat - vpp saves its api trace to this file when it crashes with api-trace enabled
+	apiPostMortemFormat = "/tmp/api_post_mortem.%d"
+)
+
+// WithCrashBundle - collects a diagnostic bundle into dir whenever vpp exits unexpectedly: the rendered vpp.conf,
+// the last lines of vpp.log and of the output of vpp, core files, the saved api trace, the exit status and timing
+// data.  If archive is true the bundle is written as a .tar.gz file instead of a directory.
+// The exit error is returned as a *CrashError carrying the path of the bundle.
+func WithCrashBundle(dir string, archive bool) Option {
+	return func(opt *option) {
+		opt.crashBundleDir = dir
+		opt.crashBundleArchive = archive
+	}
+}
+
+// CrashError - returned when vpp exited unexpectedly and a crash bundle was collected (see WithCrashBundle)
+type CrashError struct {
+	// Bundle - path of the crash bundle directory or .tar.gz file
+	Bundle string
+	// Err - the exit error of the vpp process
+	Err error
+}
+
+func (e *CrashError) Error() string {
+	return fmt.Sprintf("%v (crash bundle: %s)", e.Err, e.Bundle)
+}
+
+func (e *CrashError) Unwrap() error {
+	return e.Err
+}
+
+// crashExit - exit status and timing data of a crashed vpp process, saved as exit.json in a crash bundle
+type crashExit struct {
+	PID        int       `json:"pid"`
+	Cmd        []string  `json:"cmd"`
+	Error      string    `json:"error"`
+	ExitCode   int       `json:"exitCode"`
+	Signal     string    `json:"signal,omitempty"`
+	CoreDumped bool      `json:"coreDumped"`
+	StartedAt  time.Time `json:"startedAt"`
+	ExitedAt   time.Time `json:"exitedAt"`
+	Uptime     string    `json:"uptime"`
+	UserTime   string    `json:"userTime"`
+	SystemTime string    `json:"systemTime"`
+}
+
+// bundleFile - a file in a crash bundle, either copied from path or containing data
+type bundleFile struct {
+	name string
+	path string
+	data []byte
+}
+
+// crashBundle - collects a crash bundle for the exited vpp process and returns exitErr wrapped in a *CrashError.
+// If the bundle cannot be written exitErr is returned as it is.
+func (i *Instance) crashBundle(ctx context.Context, o *option, exitErr error) error {
+	i.mu.RLock()
+	cmd, startedAt := i.cmd, i.startedAt
+	i.mu.RUnlock()
+
+	exit := newCrashExit(cmd, startedAt, exitErr)
+	exitJSON, err := json.MarshalIndent(exit, "", "  ")
+	if err != nil {
+		log.Entry(ctx).Warnf("unable to collect crash bundle for vpp (pid %d) due to %+v", exit.PID, err)
+		return exitErr
+	}
+	logLines := i.LogTail()
+	if logLines == nil {
+		logLines = lastLines(i.paths.LogFile, crashBundleLines)
+	}
+	files := []bundleFile{
+		{name: "exit.json", data: append(exitJSON, '\n')},
+		{name: "vpp.conf", path: i.paths.ConfigFile},
+		{name: "vpp.log", data: joinLines(logLines)},
+		{name: "output.log", data: joinLines(i.output.Lines())},
+	}
+	for _, filename := range coreFiles(exit.PID, cmd.Dir, i.paths.RootDir) {
+		files = append(files, bundleFile{name: filepath.Base(filename), path: filename})
+	}
+	if apiTrace := fmt.Sprintf(apiPostMortemFormat, exit.PID); fileExists(apiTrace) {
+		files = append(files, bundleFile{name: filepath.Base(apiTrace), path: apiTrace})
+	}
+
+	bundle := filepath.Join(o.crashBundleDir, fmt.Sprintf("vpp-crash-%d-%s", exit.PID, exit.ExitedAt.Format("20060102-150405")))
+	if o.crashBundleArchive {
+		bundle += ".tar.gz"
+		err = writeBundleArchive(bundle, files)
+	} else {
+		err = writeBundleDir(bundle, files)
+	}
+	if err != nil {
+		log.Entry(ctx).Warnf("unable to collect crash bundle for vpp (pid %d) due to %+v", exit.PID, err)
+		return exitErr
+	}
+	log.Entry(ctx).Warnf("collected crash bundle for vpp (pid %d): %s", exit.PID, bundle)
+	return &CrashError{Bundle: bundle, Err: exitErr}
+}
+
+func newCrashExit(cmd *exec.Cmd, startedAt time.Time, exitErr error) *crashExit {
+	exit := &crashExit{
+		PID:       cmd.Process.Pid,
+		Cmd:       cmd.Args,
+		Error:     fmt.Sprint(exitErr),
+		StartedAt: startedAt,
+		ExitedAt:  time.Now(),
+	}
+	exit.Uptime = exit.ExitedAt.Sub(startedAt).String()
+	if state := cmd.ProcessState; state != nil {
+		exit.ExitCode = state.ExitCode()
+		exit.UserTime = state.UserTime().String()
+		exit.SystemTime = state.SystemTime().String()
+		if status, ok := state.Sys().(syscall.WaitStatus); ok && status.Signaled() {
+			exit.Signal = status.Signal().String()
+			exit.CoreDumped = status.CoreDump()
+		}
+	}
+	return exit
+}
+
+// coreFiles - returns the core files of the process with the given pid found in dirs
+func coreFiles(pid int, dirs ...string) []string {
+	var filenames []string
+	seen := make(map[string]bool)
+	for _, dir := range dirs {
+		if dir == "" {
+			var err error
+			if dir, err = os.Getwd(); err != nil {
+				continue
+			}
+		}
+		for _, name := range []string{"core", fmt.Sprintf("core.%d", pid)} {
+			filename := filepath.Join(dir, name)
+			if !seen[filename] && fileExists(filename) {
+				seen[filename] = true
+				filenames = append(filenames, filename)
+			}
+		}
+	}
+	return filenames
+}
+
+// lastLines - returns up to n last lines of filename
+func lastLines(filename string, n int) []string {
+	f, err := os.Open(filepath.Clean(filename))
+	if err != nil {
+		return nil
+	}
+	defer func() { _ = f.Close() }()
+	if info, statErr := f.Stat(); statErr == nil && info.Size() > crashBundleLogWindow {
+		if _, err = f.Seek(info.Size()-crashBundleLogWindow, io.SeekStart); err != nil {
+			return nil
+		}
+	}
+	data, err := io.ReadAll(f)
+	if err != nil {
+		return nil
+	}
+	lines := strings.Split(strings.TrimRight(string(data), "\n"), "\n")
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return lines
+}
+
+func joinLines(lines []string) []byte {
+	var buf bytes.Buffer
+	for _, line := range lines {
+		buf.WriteString(line)
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes()
+}
+
+func fileExists(filename string) bool {
+	info, err := os.Stat(filename)
+	return err == nil && info.Mode().IsRegular()
+}
+
+func (f *bundleFile) open() (io.ReadCloser, int64, error) {
+	if f.path == "" {
+		return io.NopCloser(bytes.NewReader(f.data)), int64(len(f.data)), nil
+	}
+	file, err := os.Open(filepath.Clean(f.path))
+	if err != nil {
+		return nil, 0, errors.WithStack(err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return nil, 0, errors.WithStack(err)
+	}
+	return file, info.Size(), nil
+}
+
+func writeBundleDir(dir string, files []bundleFile) error {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return errors.WithStack(err)
+	}
+	for idx := range files {
+		if err := writeBundleFile(filepath.Join(dir, files[idx].name), &files[idx]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeBundleFile(filename string, f *bundleFile) error {
+	r, _, err := f.open()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = r.Close() }()
+	out, err := os.OpenFile(filepath.Clean(filename), os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if _, err = io.Copy(out, r); err != nil {
+		_ = out.Close()
+		return errors.WithStack(err)
+	}
+	return errors.WithStack(out.Close())
+}
+
+func writeBundleArchive(filename string, files []bundleFile) error {
+	if err := os.MkdirAll(filepath.Dir(filename), 0o700); err != nil {
+		return errors.WithStack(err)
+	}
+	out, err := os.OpenFile(filepath.Clean(filename), os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer func() { _ = out.Close() }()
+
+	gz := gzip.NewWriter(out)
+	tw := tar.NewWriter(gz)
+	dir := strings.TrimSuffix(filepath.Base(filename), ".tar.gz")
+	for idx := range files {
+		if err := writeBundleArchiveFile(tw, filepath.Join(dir, files[idx].name), &files[idx]); err != nil {
+			return err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return errors.WithStack(err)
+	}
+	if err := gz.Close(); err != nil {
+		return errors.WithStack(err)
+	}
+	return errors.WithStack(out.Close())
+}
+
+func writeBundleArchiveFile(tw *tar.Writer, name string, f *bundleFile) error {
+	r, size, err := f.open()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = r.Close() }()
+	if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0o600, Size: size, ModTime: time.Now()}); err != nil {
+		return errors.WithStack(err)
+	}
+	_, err = io.CopyN(tw, r, size)
+	return errors.WithStack(err)
+}
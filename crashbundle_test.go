@@ -0,0 +1,119 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vpphelper_test
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/vpphelper"
+)
+
+// crashingVPP - puts a fake vpp first in PATH that logs, leaves a core file and an api trace behind and segfaults
+func crashingVPP(t *testing.T, rootDir string) {
+	fakeVPP(t, `ulimit -c 0
+echo "vpp[$$]: main: about to crash"
+echo "crashing" >> `+filepath.Join(rootDir, "/var/log/vpp/vpp.log")+`
+echo "core" > `+filepath.Join(rootDir, "core")+`
+echo "trace" > /tmp/api_post_mortem.$$
+kill -SEGV $$`)
+}
+
+func startCrashingVPP(t *testing.T, archive bool) (instance *vpphelper.Instance, crashErr *vpphelper.CrashError) {
+	_, _ = mockVPP(t)
+	rootDir := t.TempDir()
+	crashingVPP(t, rootDir)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	instance, err := vpphelper.StartContext(ctx, vpphelper.WithRootDir(rootDir), vpphelper.WithCrashBundle(t.TempDir(), archive))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = os.Remove(fmt.Sprintf("/tmp/api_post_mortem.%d", instance.PID())) })
+
+	err = instance.Wait()
+	require.ErrorAs(t, err, &crashErr)
+	require.EqualError(t, crashErr.Err, "signal: segmentation fault")
+	require.ErrorContains(t, err, crashErr.Bundle)
+	return instance, crashErr
+}
+
+func TestWithCrashBundle(t *testing.T) {
+	instance, crashErr := startCrashingVPP(t, false)
+
+	files, err := os.ReadDir(crashErr.Bundle)
+	require.NoError(t, err)
+	var names []string
+	for _, f := range files {
+		names = append(names, f.Name())
+	}
+	apiTrace := fmt.Sprintf("api_post_mortem.%d", instance.PID())
+	require.ElementsMatch(t, []string{"exit.json", "vpp.conf", "vpp.log", "output.log", "core", apiTrace}, names)
+
+	read := func(name string) string {
+		data, readErr := os.ReadFile(filepath.Join(crashErr.Bundle, name)) // #nosec G304
+		require.NoError(t, readErr)
+		return string(data)
+	}
+	require.Contains(t, read("vpp.conf"), instance.Paths().APISocket)
+	require.Equal(t, "crashing\n", read("vpp.log"))
+	require.Contains(t, read("output.log"), "about to crash")
+	require.Equal(t, "core\n", read("core"))
+	require.Equal(t, "trace\n", read(apiTrace))
+
+	var exit struct {
+		PID    int    `json:"pid"`
+		Signal string `json:"signal"`
+		Uptime string `json:"uptime"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(read("exit.json")), &exit))
+	require.Equal(t, instance.PID(), exit.PID)
+	require.Equal(t, "segmentation fault", exit.Signal)
+	require.NotEmpty(t, exit.Uptime)
+}
+
+func TestWithCrashBundle_Archive(t *testing.T) {
+	_, crashErr := startCrashingVPP(t, true)
+	require.True(t, strings.HasSuffix(crashErr.Bundle, ".tar.gz"))
+
+	f, err := os.Open(crashErr.Bundle)
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+	gz, err := gzip.NewReader(f)
+	require.NoError(t, err)
+	tr := tar.NewReader(gz)
+	var names []string
+	for {
+		hdr, nextErr := tr.Next()
+		if nextErr == io.EOF {
+			break
+		}
+		require.NoError(t, nextErr)
+		names = append(names, filepath.Base(hdr.Name))
+	}
+	require.Subset(t, names, []string{"exit.json", "vpp.conf", "vpp.log", "output.log", "core"})
+}
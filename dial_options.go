@@ -0,0 +1,144 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vpphelper
+
+import (
+	"math/rand"
+	"time"
+)
+
+const (
+	// DefaultInitialInterval - Default value for the first interval between connection attempts
+	DefaultInitialInterval = 10 * time.Millisecond
+	// DefaultMaxInterval - Default value for the max interval between connection attempts
+	DefaultMaxInterval = time.Second
+	// DefaultJitter - Default value for the jitter applied to the interval between connection attempts
+	DefaultJitter = 0.2
+	// DefaultKeepaliveMaxMissed - Default value for the number of consecutive missed keepalives after which a
+	// connection becomes Unhealthy
+	DefaultKeepaliveMaxMissed = 3
+
+	backoffMultiplier = 2
+)
+
+type dialOption struct {
+	initialInterval time.Duration
+	maxInterval     time.Duration
+	jitter          float64
+	maxAttempts     int
+	timeout         time.Duration
+	readinessChecks []ReadinessCheck
+
+	keepaliveInterval  time.Duration
+	keepaliveTimeout   time.Duration
+	keepaliveMaxMissed int
+}
+
+// DialOption - Option for use with DialContext(...)
+type DialOption func(opt *dialOption)
+
+func newDialOption(opts ...DialOption) *dialOption {
+	o := &dialOption{
+		initialInterval: DefaultInitialInterval,
+		maxInterval:     DefaultMaxInterval,
+		jitter:          DefaultJitter,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// WithBackoff - sets the interval before the second connection attempt and the max interval between attempts.
+// The interval is doubled after every failed attempt until it reaches maxInterval.  An initialInterval <= 0 is
+// replaced by DefaultInitialInterval and a maxInterval below initialInterval by initialInterval.
+func WithBackoff(initialInterval, maxInterval time.Duration) DialOption {
+	if initialInterval <= 0 {
+		initialInterval = DefaultInitialInterval
+	}
+	if maxInterval < initialInterval {
+		maxInterval = initialInterval
+	}
+	return func(opt *dialOption) {
+		opt.initialInterval = initialInterval
+		opt.maxInterval = maxInterval
+	}
+}
+
+// WithJitter - sets the fraction [0, 1] by which each interval between connection attempts is randomly
+// increased or decreased.  A jitter outside of [0, 1] is clamped to it.
+func WithJitter(jitter float64) DialOption {
+	jitter = min(max(jitter, 0), 1)
+	return func(opt *dialOption) {
+		opt.jitter = jitter
+	}
+}
+
+// WithMaxAttempts - sets the max number of connection attempts once the socket exists.  0 means no limit.
+func WithMaxAttempts(maxAttempts int) DialOption {
+	return func(opt *dialOption) {
+		opt.maxAttempts = maxAttempts
+	}
+}
+
+// WithDialTimeout - sets the overall deadline for waiting for the socket and connecting to it.  0 means no limit.
+// The deadline applies separately to the initial dial and to each reconnect.
+func WithDialTimeout(timeout time.Duration) DialOption {
+	return func(opt *dialOption) {
+		opt.timeout = timeout
+	}
+}
+
+// WithKeepalive - sends a control_ping every interval once connected.  A ping that fails or gets no reply within
+// timeout is missed.  After maxMissed consecutive misses the connection becomes Unhealthy and is re-established.
+// An interval <= 0 disables keepalives, a timeout <= 0 is replaced by interval and a maxMissed <= 0 by
+// DefaultKeepaliveMaxMissed.
+func WithKeepalive(interval, timeout time.Duration, maxMissed int) DialOption {
+	if timeout <= 0 {
+		timeout = interval
+	}
+	if maxMissed <= 0 {
+		maxMissed = DefaultKeepaliveMaxMissed
+	}
+	return func(opt *dialOption) {
+		opt.keepaliveInterval = interval
+		opt.keepaliveTimeout = timeout
+		opt.keepaliveMaxMissed = maxMissed
+	}
+}
+
+// interval - returns the interval to wait after the given number of failed attempts
+func (o *dialOption) interval(attempts int) time.Duration {
+	return backoffInterval(o.initialInterval, o.maxInterval, o.jitter, attempts)
+}
+
+// backoffInterval - returns the interval to wait after the given number of failed attempts, doubling
+// initialInterval after every attempt up to maxInterval and applying jitter
+func backoffInterval(initialInterval, maxInterval time.Duration, jitter float64, attempts int) time.Duration {
+	interval := initialInterval
+	for i := 1; i < attempts && interval < maxInterval; i++ {
+		interval *= backoffMultiplier
+	}
+	if interval > maxInterval {
+		interval = maxInterval
+	}
+	if jitter > 0 {
+		// #nosec G404 - jitter does not need a cryptographically secure random number
+		interval += time.Duration(jitter * (2*rand.Float64() - 1) * float64(interval))
+	}
+	return interval
+}
@@ -0,0 +1,141 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vpphelper
+
+import (
+	"fmt"
+	"os/exec"
+	"regexp"
+	"sync"
+	"syscall"
+
+	"github.com/pkg/errors"
+)
+
+// ExitKind - how a vpp process ended
+type ExitKind int
+
+const (
+	// ExitClean - vpp exited on its own with exit code 0
+	ExitClean ExitKind = iota
+	// ExitNonZero - vpp exited with a non-zero exit code
+	ExitNonZero
+	// ExitSignaled - vpp was killed by a signal
+	ExitSignaled
+	// ExitExecFailed - vpp could not be executed, for example because the binary was not found or is not executable
+	ExitExecFailed
+	// ExitConfigRejected - vpp exited with a non-zero exit code after printing a configuration error to stderr
+	// before creating its api socket
+	ExitConfigRejected
+)
+
+func (k ExitKind) String() string {
+	switch k {
+	case ExitClean:
+		return "Clean"
+	case ExitNonZero:
+		return "NonZero"
+	case ExitSignaled:
+		return "Signaled"
+	case ExitExecFailed:
+		return "ExecFailed"
+	case ExitConfigRejected:
+		return "ConfigRejected"
+	}
+	return fmt.Sprintf("ExitKind(%d)", int(k))
+}
+
+// configErrorRegexp - matches the lines vpp prints when it rejects its configuration
+var configErrorRegexp = regexp.MustCompile("unknown input|parse error|unknown parameter|config(uration)? error")
+
+// ExitError - the reason a vpp process ended.  Use errors.As to tell the kinds of exit apart, errors.Is(err,
+// exec.ErrNotFound) and errors.Is(err, fs.ErrPermission) work for exec failures.
+type ExitError struct {
+	Kind ExitKind
+	// Code - the exit code for ExitNonZero and ExitConfigRejected
+	Code int
+	// Signal - the signal for ExitSignaled
+	Signal syscall.Signal
+	// Reason - the configuration error printed by vpp for ExitConfigRejected
+	Reason string
+	// Err - the underlying error, nil for ExitClean
+	Err error
+}
+
+func (e *ExitError) Error() string {
+	switch e.Kind {
+	case ExitClean:
+		return "vpp exited cleanly"
+	case ExitExecFailed:
+		return fmt.Sprintf("unable to exec vpp: %v", e.Err)
+	case ExitConfigRejected:
+		return fmt.Sprintf("vpp rejected its configuration: %s: %v", e.Reason, e.Err)
+	}
+	return fmt.Sprint(e.Err)
+}
+
+func (e *ExitError) Unwrap() error {
+	return e.Err
+}
+
+// configErrorWatcher - remembers the first configuration error vpp printed to stderr at startup
+type configErrorWatcher struct {
+	mu      sync.Mutex
+	reason  string
+	stopped bool
+}
+
+func (w *configErrorWatcher) hook() logHook {
+	return logHook{pattern: configErrorRegexp, hook: func(line LogLine) {
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		if w.reason == "" && !w.stopped {
+			w.reason = line.Message
+		}
+	}}
+}
+
+// stop - stops watching once vpp is done with its configuration, later errors are not configuration errors
+func (w *configErrorWatcher) stop() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.stopped = true
+}
+
+func (w *configErrorWatcher) Reason() string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.reason
+}
+
+// newExitError - classifies the error returned by exec.Cmd.Wait.  A nil err is a clean exit.
+func newExitError(err error, configError string) *ExitError {
+	if err == nil {
+		return &ExitError{Kind: ExitClean}
+	}
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) {
+		return &ExitError{Kind: ExitNonZero, Code: -1, Err: err}
+	}
+	if status, ok := exitErr.Sys().(syscall.WaitStatus); ok && status.Signaled() {
+		return &ExitError{Kind: ExitSignaled, Code: -1, Signal: status.Signal(), Err: err}
+	}
+	if configError != "" {
+		return &ExitError{Kind: ExitConfigRejected, Code: exitErr.ExitCode(), Reason: configError, Err: err}
+	}
+	return &ExitError{Kind: ExitNonZero, Code: exitErr.ExitCode(), Err: err}
+}
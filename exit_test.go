@@ -0,0 +1,124 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vpphelper_test
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/vpphelper"
+)
+
+func TestExitError(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		body   string
+		kind   vpphelper.ExitKind
+		code   int
+		signal syscall.Signal
+		reason string
+	}{
+		{name: "Clean", body: "exit 0", kind: vpphelper.ExitClean},
+		{name: "NonZero", body: "exit 3", kind: vpphelper.ExitNonZero, code: 3},
+		{name: "Signaled", body: "kill -ABRT $$", kind: vpphelper.ExitSignaled, code: -1, signal: syscall.SIGABRT},
+		{
+			name:   "ConfigRejected",
+			body:   "echo \"vpp[$$]: unix_config: unknown input 'foo'\" >&2\nexit 1",
+			kind:   vpphelper.ExitConfigRejected,
+			code:   1,
+			reason: "unknown input 'foo'",
+		},
+		{
+			// configuration errors are reported on stderr
+			name: "ErrorOnStdout",
+			body: "echo \"vpp[$$]: unix_config: unknown input 'foo'\"\nexit 1",
+			kind: vpphelper.ExitNonZero,
+			code: 1,
+		},
+		{
+			// once vpp created its api socket it is done with its configuration
+			name: "ErrorAfterStartup",
+			body: "touch \"${2%/etc/vpp/helper/vpp.conf}/var/run/vpp/api.sock\"\nsleep 0.2\n" +
+				"echo \"vpp[$$]: set: unknown input 'foo'\" >&2\nexit 1",
+			kind: vpphelper.ExitNonZero,
+			code: 1,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, _ = mockVPP(t)
+			fakeVPP(t, "ulimit -c 0\n"+tc.body)
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			instance, err := vpphelper.StartContext(ctx, vpphelper.WithRootDir(t.TempDir()))
+			require.NoError(t, err)
+
+			var exitErr *vpphelper.ExitError
+			require.ErrorAs(t, instance.Wait(), &exitErr)
+			require.Equal(t, tc.kind, exitErr.Kind)
+			require.Equal(t, tc.code, exitErr.Code)
+			require.Equal(t, tc.signal, exitErr.Signal)
+			require.Equal(t, tc.reason, exitErr.Reason)
+		})
+	}
+}
+
+func TestStartAndDialContext_CleanExit(t *testing.T) {
+	_, _ = mockVPP(t)
+	fakeVPP(t, "exit 0")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_, errCh := vpphelper.StartAndDialContext(ctx, vpphelper.WithRootDir(t.TempDir()))
+	err, ok := <-errCh
+	require.NoError(t, err)
+	require.False(t, ok)
+}
+
+func TestExitError_ExecFailed(t *testing.T) {
+	notExecutable := filepath.Join(t.TempDir(), "vpp")
+	require.NoError(t, os.WriteFile(notExecutable, []byte("#!/bin/sh\n"), 0o600))
+
+	for _, tc := range []struct {
+		name   string
+		binary string
+		target error
+	}{
+		{name: "NotFound", binary: "vpp-does-not-exist", target: exec.ErrNotFound},
+		{name: "PermissionDenied", binary: notExecutable, target: fs.ErrPermission},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			_, err := vpphelper.StartContext(ctx, vpphelper.WithRootDir(t.TempDir()), vpphelper.WithVPPBinary(tc.binary))
+
+			var exitErr *vpphelper.ExitError
+			require.ErrorAs(t, err, &exitErr)
+			require.Equal(t, vpphelper.ExitExecFailed, exitErr.Kind)
+			require.True(t, errors.Is(err, tc.target), err.Error())
+		})
+	}
+}
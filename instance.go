@@ -0,0 +1,491 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vpphelper
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/edwarnicke/exechelper"
+	"github.com/edwarnicke/log"
+	"github.com/pkg/errors"
+)
+
+// ErrShutdownTimeout - vpp did not exit within the grace period after SIGTERM and was killed with SIGKILL
+var ErrShutdownTimeout = errors.New("vpp did not exit within the grace period")
+
+// ShutdownTimeoutError - returned when vpp did not exit within the grace period and was killed with SIGKILL.  It
+// matches ErrShutdownTimeout and wraps the exit error of vpp, an *ExitError.
+type ShutdownTimeoutError struct {
+	GracePeriod time.Duration
+	Err         error
+}
+
+func (e *ShutdownTimeoutError) Error() string {
+	return fmt.Sprintf("%v (%s), killed: %v", ErrShutdownTimeout, e.GracePeriod, e.Err)
+}
+
+// Unwrap - returns ErrShutdownTimeout and the exit error of vpp
+func (e *ShutdownTimeoutError) Unwrap() []error {
+	return []error{ErrShutdownTimeout, e.Err}
+}
+
+// Paths - locations of the files used by a vpp Instance
+type Paths struct {
+	RootDir     string
+	APISocket   string
+	CLISocket   string
+	StatsSocket string
+	LogFile     string
+	ConfigFile  string
+	// LockFile - locked by the Instance that started vpp for as long as vpp runs
+	LockFile string
+}
+
+func newPaths(rootDir string) Paths {
+	return Paths{
+		RootDir:     rootDir,
+		APISocket:   filepath.Join(rootDir, apiSockFilename),
+		CLISocket:   filepath.Join(rootDir, cliSockFilename),
+		StatsSocket: filepath.Join(rootDir, statsSockFilename),
+		LogFile:     filepath.Join(rootDir, logFilename),
+		ConfigFile:  filepath.Join(rootDir, vppConfFilename),
+		LockFile:    filepath.Join(rootDir, lockFilename),
+	}
+}
+
+// CrashLoopError - returned once a supervised vpp exited more often than allowed within the restart window
+type CrashLoopError struct {
+	// Restarts - number of restarts within Window
+	Restarts int
+	Window   time.Duration
+	// Err - the exit error of the last vpp process
+	Err error
+}
+
+func (e *CrashLoopError) Error() string {
+	return fmt.Sprintf("vpp was restarted %d times within %s, giving up: %v", e.Restarts, e.Window, e.Err)
+}
+
+func (e *CrashLoopError) Unwrap() error {
+	return e.Err
+}
+
+// Instance - handle for a vpp process started with StartContext
+type Instance struct {
+	paths  Paths
+	conn   Connection
+	cancel context.CancelFunc
+	done   chan struct{}
+	err    error
+	tail   *logTail
+	output *lineRing
+	cgroup *cgroup
+	// startupCLI - collects the output of the startup cli commands, nil if there are none
+	startupCLI *startupCLIWatcher
+	// attached - true if vpp was already running and was not started by the Instance
+	attached bool
+
+	mu        sync.RWMutex
+	cmd       *exec.Cmd
+	startedAt time.Time
+}
+
+// StartContext - starts vpp and returns a handle for it.  The vpp process is stopped gracefully when ctx is done
+// or Stop is called: the api connection is closed, vpp gets SIGTERM and, if it has not exited within the grace
+// period, SIGKILL.
+// With WithRestart vpp is supervised: it is restarted whenever it exits and the Connection returned by Conn()
+// reconnects to it.
+// Every line vpp prints to Stdout and Stderr is logged to log.Entry(ctx) at the level parsed from it.
+// With WithCrashBundle a diagnostic bundle is collected whenever vpp exits unexpectedly.
+// With WithAttachOrStart an already running vpp is used instead of starting a new one.
+// With WithNetNS vpp runs in the given network namespace and with WithCgroup in the given cgroup v2.
+// vpp runs in its own process group, which is killed once vpp exited, and on linux it is killed when the calling
+// process dies.
+// With WithStartupCLI StartContext returns once vpp ran the startup cli commands.
+// The root dir is locked while vpp runs; StartContext fails with a *RootDirInUseError if it is already locked.
+func StartContext(ctx context.Context, opts ...Option) (*Instance, error) {
+	o := newOption(opts...)
+	paths := newPaths(o.rootDir)
+
+	if o.attachOrStart {
+		if len(o.startupCLI) > 0 {
+			return nil, errors.New("WithStartupCLI cannot be combined with WithAttachOrStart, an attached vpp does not run the startup cli commands")
+		}
+		err := probeVPP(ctx, paths.APISocket)
+		if err == nil {
+			return attach(ctx, paths), nil
+		}
+		log.Entry(ctx).Infof("no live vpp on %s (%v), starting one", paths.APISocket, err)
+	}
+
+	i := &Instance{
+		paths:  paths,
+		output: newLineRing(crashBundleLines),
+		done:   make(chan struct{}),
+	}
+	teardown, err := i.prepare(ctx, o)
+	if err != nil {
+		return nil, err
+	}
+
+	var vppCtx context.Context
+	vppCtx, i.cancel = context.WithCancel(ctx)
+	vppErrCh, err := i.start(vppCtx, o)
+	if err != nil {
+		i.cancel()
+		teardown()
+		return nil, err
+	}
+
+	// The connection is not torn down by Stop cancelling vppCtx but disconnected before vpp is signaled, so that
+	// its Err() is ErrClosed afterwards
+	i.conn = DialContext(context.WithoutCancel(vppCtx), i.paths.APISocket)
+	go func() {
+		i.err = i.supervise(vppCtx, vppErrCh, o)
+		i.conn.Disconnect()
+		teardown()
+		close(i.done)
+	}()
+
+	if i.startupCLI != nil {
+		if err = i.waitStartupCLI(ctx, o.startupCLITimeout); err != nil {
+			return nil, err
+		}
+	}
+	return i, nil
+}
+
+// prepare - locks the root dir and writes the config files, sets up the cgroup and starts following the log of vpp
+// as requested by o.  The returned teardown undoes it once vpp is gone for good.
+func (i *Instance) prepare(ctx context.Context, o *option) (teardown func(), err error) {
+	lock, err := lockRootDir(ctx, i.paths)
+	if err != nil {
+		return nil, err
+	}
+	if err = writeDefaultConfigFiles(ctx, o); err == nil && len(o.startupCLI) > 0 {
+		i.startupCLI = newStartupCLIWatcher(o.startupCLI)
+		err = checkStartupCLIConfig(i.paths.ConfigFile, startupCLIConfig(o))
+	}
+	if err == nil {
+		i.cgroup, err = newCgroup(ctx, o)
+	}
+	if err != nil {
+		lock.unlock()
+		return nil, err
+	}
+
+	stopLogTail := i.startLogTail(ctx, o)
+	return func() {
+		stopLogTail()
+		if !o.keepRuntimeFiles {
+			removeRuntimeFiles(ctx, i.paths)
+		}
+		if i.cgroup != nil {
+			i.cgroup.remove()
+		}
+		lock.unlock()
+	}, nil
+}
+
+// start - starts a vpp process and returns a channel that receives its exit error, if any, and is closed once
+// it exited
+func (i *Instance) start(ctx context.Context, o *option) (<-chan error, error) {
+	configErrors := &configErrorWatcher{}
+	stdout, stderr := i.newOutput(ctx, o, configErrors)
+	closeOutput := func() {
+		_ = stdout.Close()
+		_ = stderr.Close()
+	}
+	// Stale sockets would be taken for those of the new vpp
+	removeRuntimeFiles(ctx, i.paths)
+	vppCmd, vppErrCh, err := i.exec(ctx, o, stdout, stderr)
+	if err != nil {
+		closeOutput()
+		return nil, err
+	}
+
+	reaper, reaperErr := startGroupReaper(vppCmd.Process.Pid)
+	if reaperErr != nil {
+		log.Entry(ctx).Warnf("processes forked by vpp (pid %d) may outlive this process: %+v", vppCmd.Process.Pid, reaperErr)
+	}
+
+	i.mu.Lock()
+	i.cmd = vppCmd
+	i.startedAt = time.Now()
+	i.mu.Unlock()
+
+	// vpp is done with its configuration once it created its api socket
+	startupCtx, startupDone := context.WithCancel(ctx)
+	go func() {
+		if waitForSocket(startupCtx, i.paths.APISocket) == nil {
+			configErrors.stop()
+		}
+	}()
+
+	errCh := make(chan error, 1)
+	go func() {
+		// exechelper sends at most one error; the output is flushed before it is forwarded so that
+		// everything vpp printed has been seen once it exited
+		var exitErr error
+		for err := range vppErrCh {
+			exitErr = err
+		}
+		startupDone()
+		closeOutput()
+		if err := signalGroup(vppCmd.Process, syscall.SIGKILL); err == nil {
+			log.Entry(ctx).Debugf("killed the processes left in the process group of vpp (pid %d)", vppCmd.Process.Pid)
+		}
+		if reaper != nil {
+			reaper.stop()
+		}
+		if exitErr != nil {
+			errCh <- newExitError(exitErr, configErrors.Reason())
+		}
+		close(errCh)
+	}()
+	return errCh, nil
+}
+
+// newOutput - returns the writers for the stdout and stderr of vpp, which log every line and call the hooks of the
+// Instance for it.  vpp reports configuration errors on stderr, so only stderr is watched by configErrors.
+func (i *Instance) newOutput(ctx context.Context, o *option, configErrors *configErrorWatcher) (stdout, stderr *logWriter) {
+	hooks := append([]logHook{}, o.logHooks...)
+	if o.crashBundleDir != "" {
+		hooks = append(hooks, i.output.hook())
+	}
+	if i.startupCLI != nil {
+		hooks = append(hooks, i.startupCLI.hook())
+	}
+	// We need to reset time in logger to make sure that
+	// we don't use a static timestamp for a long-running process
+	entry := log.Entry(ctx).WithField("cmd", "vpp").WithTime(time.Time{})
+	return newLogWriter(entry, hooks), newLogWriter(entry, append(hooks, configErrors.hook()))
+}
+
+// exec - starts the vpp process with its output going to stdout and stderr, in the network namespace and cgroup
+// requested by o.  It returns the *exec.Cmd of vpp and the channel exechelper reports its exit on.
+func (i *Instance) exec(ctx context.Context, o *option, stdout, stderr io.Writer) (*exec.Cmd, <-chan error, error) {
+	argv := o.argv(i.paths.ConfigFile)
+	log.Entry(ctx).Infof("starting vpp: %q", argv)
+	procAttr := groupProcAttr()
+	if i.cgroup != nil {
+		// vpp is started in its cgroup rather than moved there, so that nothing it forks first escapes it
+		cgroupDir, err := i.cgroup.open()
+		if err != nil {
+			return nil, nil, err
+		}
+		defer func() { _ = cgroupDir.Close() }()
+		inCgroup(procAttr, cgroupDir)
+	}
+	var vppCmd *exec.Cmd
+	execOpts := execOptions(o, argv[1:], stdout, stderr, func(cmd *exec.Cmd) {
+		cmd.SysProcAttr = procAttr
+		vppCmd = cmd
+	})
+	var vppErrCh <-chan error
+	if err := inNetNS(o.netNS, func() {
+		// Only argv[0] is parsed by exechelper, the remaining arguments are passed as they are
+		vppErrCh = exechelper.Start(shellQuote(argv[0]), execOpts...)
+	}); err != nil {
+		return nil, nil, err
+	}
+	select {
+	case err := <-vppErrCh:
+		return nil, nil, &ExitError{Kind: ExitExecFailed, Code: -1, Err: err}
+	default:
+	}
+	return vppCmd, vppErrCh, nil
+}
+
+// execOptions - returns the exechelper options for running vpp with args and its output going to stdout and
+// stderr.  setCmd is called with the *exec.Cmd of vpp and has to set its SysProcAttr.
+func execOptions(o *option, args []string, stdout, stderr io.Writer, setCmd func(cmd *exec.Cmd)) []*exechelper.Option {
+	execOpts := []*exechelper.Option{
+		exechelper.WithArgs(args...),
+		exechelper.WithStdout(stdout),
+		exechelper.WithStderr(stderr),
+		exechelper.CmdOption(func(cmd *exec.Cmd) error {
+			if len(o.env) > 0 {
+				cmd.Env = append(os.Environ(), o.env...)
+			}
+			setCmd(cmd)
+			return nil
+		}),
+	}
+	return append(execOpts, deathSignalOptions()...)
+}
+
+// supervise - waits for vpp to exit and restarts it as allowed by o
+func (i *Instance) supervise(ctx context.Context, vppErrCh <-chan error, o *option) error {
+	var restarts []time.Time
+	var startErr error
+	for {
+		err := i.wait(ctx, vppErrCh, o.gracePeriod)
+		if ctx.Err() == nil && startErr == nil && o.crashBundleDir != "" {
+			err = i.crashBundle(ctx, o, err)
+		}
+		if ctx.Err() != nil || o.maxRestarts <= 0 {
+			// Nothing is going to create the api socket again
+			i.conn.Disconnect()
+			return err
+		}
+		log.Entry(ctx).Warnf("vpp (pid %d) exited due to %v", i.PID(), err)
+
+		now := time.Now()
+		for len(restarts) > 0 && now.Sub(restarts[0]) > o.restartWindow {
+			restarts = restarts[1:]
+		}
+		if len(restarts) >= o.maxRestarts {
+			i.conn.Disconnect()
+			return &CrashLoopError{Restarts: len(restarts), Window: o.restartWindow, Err: err}
+		}
+
+		interval := backoffInterval(o.restartInitialInterval, o.restartMaxInterval, 0, len(restarts)+1)
+		log.Entry(ctx).Infof("restarting vpp in %s (restart %d/%d within %s)", interval, len(restarts)+1, o.maxRestarts, o.restartWindow)
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			i.conn.Disconnect()
+			return err
+		case <-timer.C:
+		}
+
+		restarts = append(restarts, time.Now())
+		if vppErrCh, startErr = i.start(ctx, o); startErr != nil {
+			// The previous vpp was reaped already, its pid and process group may be reused and must not be signaled
+			i.mu.Lock()
+			i.cmd = nil
+			i.mu.Unlock()
+			vppErrCh = errorCh(startErr)
+		}
+	}
+}
+
+// wait - waits for vpp to exit and shuts it down once ctx is done.  vpp exiting cleanly on its own is reported
+// as an *ExitError of kind ExitClean, exiting cleanly after SIGTERM as nil.
+func (i *Instance) wait(ctx context.Context, vppErrCh <-chan error, gracePeriod time.Duration) error {
+	select {
+	case err := <-vppErrCh:
+		if err == nil {
+			return newExitError(nil, "")
+		}
+		return err
+	case <-ctx.Done():
+	}
+
+	i.mu.RLock()
+	cmd := i.cmd
+	i.mu.RUnlock()
+
+	i.conn.Disconnect()
+	if cmd == nil {
+		// vpp failed to restart, there is no process to stop
+		return <-vppErrCh
+	}
+	process := cmd.Process
+	log.Entry(ctx).Infof("sending SIGTERM to vpp (pid %d)", process.Pid)
+	if err := signalGroup(process, syscall.SIGTERM); err != nil {
+		log.Entry(ctx).Debugf("unable to send SIGTERM to vpp (pid %d) due to %+v", process.Pid, err)
+	}
+
+	timer := time.NewTimer(gracePeriod)
+	defer timer.Stop()
+	select {
+	case err := <-vppErrCh:
+		return err
+	case <-timer.C:
+	}
+
+	log.Entry(ctx).Warnf("vpp (pid %d) did not exit within %s, sending SIGKILL", process.Pid, gracePeriod)
+	if err := signalGroup(process, syscall.SIGKILL); err != nil {
+		log.Entry(ctx).Debugf("unable to send SIGKILL to vpp (pid %d) due to %+v", process.Pid, err)
+	}
+	return &ShutdownTimeoutError{GracePeriod: gracePeriod, Err: <-vppErrCh}
+}
+
+// shellQuote - quotes s so that it is parsed as a single word
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'"'"'`) + "'"
+}
+
+// PID - returns the pid of the current vpp process, 0 if the Instance is attached to an already running vpp or
+// restarting vpp failed
+func (i *Instance) PID() int {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	if i.cmd == nil {
+		return 0
+	}
+	return i.cmd.Process.Pid
+}
+
+// Paths - returns the locations of the files used by vpp
+func (i *Instance) Paths() Paths {
+	return i.paths
+}
+
+// Conn - returns the connection to the vpp api socket, which is disconnected once vpp is not going to be restarted
+func (i *Instance) Conn() Connection {
+	return i.conn
+}
+
+// LogTail - returns the last lines of the vpp log file if it is followed (see WithLogTail), nil otherwise
+func (i *Instance) LogTail() []string {
+	if i.tail == nil {
+		return nil
+	}
+	return i.tail.Lines()
+}
+
+// Stop - disconnects from vpp, sends SIGTERM to the vpp process and waits for it to exit or ctx to be done.
+// If vpp does not exit within the grace period it is killed with SIGKILL and Stop returns a *ShutdownTimeoutError.
+// An attached vpp (see WithAttachOrStart) is only disconnected from.
+func (i *Instance) Stop(ctx context.Context) error {
+	i.cancel()
+	select {
+	case <-i.done:
+		if errors.Is(i.err, ErrShutdownTimeout) {
+			return i.err
+		}
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Wait - waits for the vpp process to exit and returns its exit error, an *ExitError.  It is not nil even if vpp
+// exited cleanly on its own: the Kind is ExitClean then.  For a supervised vpp Wait returns once it was stopped or
+// a *CrashLoopError once it gave up restarting it.
+func (i *Instance) Wait() error {
+	<-i.done
+	return i.err
+}
+
+// Done - returns a channel that is closed once the vpp process exited and will not be restarted
+func (i *Instance) Done() <-chan struct{} {
+	return i.done
+}
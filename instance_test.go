@@ -0,0 +1,204 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vpphelper_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/vpphelper"
+)
+
+// fakeVPP - puts a "vpp" shell script with the given body first in PATH
+func fakeVPP(t *testing.T, body string) {
+	binDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(binDir, "vpp"), []byte("#!/bin/sh\n"+body+"\n"), 0o700)) // #nosec G306
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+}
+
+// readFile - returns the contents of filename
+func readFile(t *testing.T, filename string) string {
+	data, err := os.ReadFile(filename) // #nosec G304
+	require.NoError(t, err)
+	return string(data)
+}
+
+func TestStartContext(t *testing.T) {
+	_, _ = mockVPP(t)
+	fakeVPP(t, "exec sleep 60")
+	rootDir := t.TempDir()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	instance, err := vpphelper.StartContext(ctx, vpphelper.WithRootDir(rootDir))
+	require.NoError(t, err)
+
+	require.Positive(t, instance.PID())
+	require.Equal(t, filepath.Join(rootDir, "/var/run/vpp/api.sock"), instance.Paths().APISocket)
+	require.Equal(t, filepath.Join(rootDir, "/var/run/vpp/cli.sock"), instance.Paths().CLISocket)
+	require.Equal(t, filepath.Join(rootDir, "/var/run/vpp/stats.sock"), instance.Paths().StatsSocket)
+	require.Equal(t, filepath.Join(rootDir, "/var/log/vpp/vpp.log"), instance.Paths().LogFile)
+	require.FileExists(t, instance.Paths().ConfigFile)
+	require.Equal(t, vpphelper.Connecting, instance.Conn().State())
+
+	select {
+	case <-instance.Done():
+		require.FailNow(t, "vpp exited before Stop")
+	default:
+	}
+	require.NoError(t, instance.Stop(ctx))
+	require.EqualError(t, instance.Wait(), "signal: terminated")
+	require.ErrorIs(t, instance.Conn().Err(), vpphelper.ErrClosed)
+}
+
+func TestStartContext_ShutdownTimeout(t *testing.T) {
+	_, _ = mockVPP(t)
+	fakeVPP(t, "trap '' TERM\nwhile true; do sleep 0.01; done")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	instance, err := vpphelper.StartContext(ctx, vpphelper.WithRootDir(t.TempDir()), vpphelper.WithGracePeriod(100*time.Millisecond))
+	require.NoError(t, err)
+	// Give the shell time to install its trap
+	time.Sleep(100 * time.Millisecond)
+
+	err = instance.Stop(ctx)
+	require.ErrorIs(t, err, vpphelper.ErrShutdownTimeout)
+	require.EqualError(t, err, "vpp did not exit within the grace period (100ms), killed: signal: killed")
+	var exitErr *vpphelper.ExitError
+	require.ErrorAs(t, err, &exitErr)
+	require.Equal(t, vpphelper.ExitSignaled, exitErr.Kind)
+	require.Equal(t, syscall.SIGKILL, exitErr.Signal)
+	require.ErrorIs(t, instance.Wait(), vpphelper.ErrShutdownTimeout)
+}
+
+func TestStartAndDialContext_Exit(t *testing.T) {
+	_, _ = mockVPP(t)
+	fakeVPP(t, "exit 3")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_, errCh := vpphelper.StartAndDialContext(ctx, vpphelper.WithRootDir(t.TempDir()))
+	require.EqualError(t, <-errCh, "exit status 3")
+}
+
+func TestStartContext_Restart(t *testing.T) {
+	_, _ = mockVPP(t)
+	// The first vpp crashes, the second one keeps running
+	runs := filepath.Join(t.TempDir(), "runs")
+	fakeVPP(t, "echo run >> "+runs+"\nif [ $(wc -l < "+runs+") -lt 2 ]; then exit 1; fi\nexec sleep 60")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	instance, err := vpphelper.StartContext(ctx,
+		vpphelper.WithRootDir(t.TempDir()),
+		vpphelper.WithRestart(3, time.Minute),
+		vpphelper.WithRestartBackoff(time.Millisecond, time.Millisecond),
+	)
+	require.NoError(t, err)
+	firstPID := instance.PID()
+
+	require.Eventually(t, func() bool {
+		return instance.PID() != firstPID
+	}, time.Second, 10*time.Millisecond)
+	select {
+	case <-instance.Done():
+		require.FailNow(t, "restarted vpp is not supervised")
+	case <-time.After(50 * time.Millisecond):
+	}
+	require.NoError(t, instance.Stop(ctx))
+}
+
+func TestStartContext_CrashLoop(t *testing.T) {
+	_, _ = mockVPP(t)
+	fakeVPP(t, "exit 1")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	instance, err := vpphelper.StartContext(ctx,
+		vpphelper.WithRootDir(t.TempDir()),
+		vpphelper.WithRestart(3, time.Minute),
+		vpphelper.WithRestartBackoff(time.Millisecond, time.Millisecond),
+	)
+	require.NoError(t, err)
+
+	var crashLoopErr *vpphelper.CrashLoopError
+	require.ErrorAs(t, instance.Wait(), &crashLoopErr)
+	require.Equal(t, 3, crashLoopErr.Restarts)
+	require.EqualError(t, crashLoopErr.Err, "exit status 1")
+	require.ErrorIs(t, instance.Conn().Err(), vpphelper.ErrClosed)
+}
+
+func TestStartContext_RestartFailed(t *testing.T) {
+	_, _ = mockVPP(t)
+	// vpp removes itself, so restarting it fails
+	fakeVPP(t, "rm -f \"$0\"\nexit 1")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	instance, err := vpphelper.StartContext(ctx,
+		vpphelper.WithRootDir(t.TempDir()),
+		vpphelper.WithRestart(1, time.Minute),
+		vpphelper.WithRestartBackoff(time.Millisecond, time.Millisecond),
+	)
+	require.NoError(t, err)
+
+	var crashLoopErr *vpphelper.CrashLoopError
+	require.ErrorAs(t, instance.Wait(), &crashLoopErr)
+	var exitErr *vpphelper.ExitError
+	require.ErrorAs(t, crashLoopErr.Err, &exitErr)
+	require.Equal(t, vpphelper.ExitExecFailed, exitErr.Kind)
+	// The reaped vpp is forgotten, so that its pid is not signaled
+	require.Zero(t, instance.PID())
+}
+
+func TestStartContext_Command(t *testing.T) {
+	_, _ = mockVPP(t)
+	binDir := filepath.Join(t.TempDir(), "custom build")
+	require.NoError(t, os.MkdirAll(binDir, 0o700))
+	out := filepath.Join(t.TempDir(), "out")
+	vppBinary := filepath.Join(binDir, "vpp")
+	script := "#!/bin/sh\nprintf '%s\\n' \"$@\" \"$VPP_TEST\" > '" + out + ".tmp'\nmv '" + out + ".tmp' '" + out + "'\nexec sleep 60\n"
+	require.NoError(t, os.WriteFile(vppBinary, []byte(script), 0o700)) // #nosec G306
+	rootDir := t.TempDir()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	instance, err := vpphelper.StartContext(ctx,
+		vpphelper.WithRootDir(rootDir),
+		vpphelper.WithVPPBinary(vppBinary),
+		vpphelper.WithExtraArgs("--extra arg", "it's"),
+		vpphelper.WithExecWrapper([]string{"env", "VPP_WRAPPED=1"}),
+		vpphelper.WithEnv("VPP_TEST=env value"),
+	)
+	require.NoError(t, err)
+	defer func() { _ = instance.Stop(ctx) }()
+
+	require.Eventually(t, func() bool {
+		_, statErr := os.Stat(out)
+		return statErr == nil
+	}, time.Second, 10*time.Millisecond)
+	args, err := os.ReadFile(filepath.Clean(out))
+	require.NoError(t, err)
+	require.Equal(t, "-c\n"+instance.Paths().ConfigFile+"\n--extra arg\nit's\nenv value\n", string(args))
+}
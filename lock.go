@@ -0,0 +1,111 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vpphelper
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/edwarnicke/log"
+	"github.com/pkg/errors"
+)
+
+// ErrRootDirInUse - the root dir is locked by another Instance, see *RootDirInUseError
+var ErrRootDirInUse = errors.New("root dir is in use")
+
+// RootDirInUseError - returned by StartContext when another Instance, possibly in another process, holds the lock
+// on the root dir
+type RootDirInUseError struct {
+	RootDir string
+	// PID - pid of the process holding the lock, 0 if unknown
+	PID int
+}
+
+func (e *RootDirInUseError) Error() string {
+	return fmt.Sprintf("%v: %q is locked by pid %d", ErrRootDirInUse, e.RootDir, e.PID)
+}
+
+// Is - makes errors.Is(err, ErrRootDirInUse) true for a *RootDirInUseError
+func (e *RootDirInUseError) Is(target error) bool {
+	return target == ErrRootDirInUse
+}
+
+// rootDirLock - exclusive flock on the lock file of a root dir.  The lock is released by the kernel when the
+// process holding it dies, so a lock file left behind by a dead process does not block anyone.
+type rootDirLock struct {
+	file *os.File
+}
+
+func lockRootDir(ctx context.Context, paths Paths) (*rootDirLock, error) {
+	if err := os.MkdirAll(filepath.Dir(paths.LockFile), 0o700); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	file, err := os.OpenFile(paths.LockFile, os.O_RDWR|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if err = flock(file); err != nil {
+		pid := readLockPID(file)
+		_ = file.Close()
+		if errors.Is(err, syscall.EWOULDBLOCK) {
+			return nil, &RootDirInUseError{RootDir: paths.RootDir, PID: pid}
+		}
+		return nil, errors.Wrapf(err, "unable to lock %s", paths.LockFile)
+	}
+
+	if pid := readLockPID(file); pid != 0 && pid != os.Getpid() {
+		log.Entry(ctx).Warnf("taking over the stale lock on %q left by pid %d", paths.RootDir, pid)
+	}
+	if err = writeLockPID(file); err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+	return &rootDirLock{file: file}, nil
+}
+
+// unlock - releases the lock.  The pid is removed from the lock file first so that the next owner does not take
+// it for a stale lock.
+func (l *rootDirLock) unlock() {
+	_ = l.file.Truncate(0)
+	_ = l.file.Close()
+}
+
+func readLockPID(file *os.File) int {
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return 0
+	}
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return 0
+	}
+	pid, _ := strconv.Atoi(strings.TrimSpace(string(data)))
+	return pid
+}
+
+func writeLockPID(file *os.File) error {
+	if err := file.Truncate(0); err != nil {
+		return errors.WithStack(err)
+	}
+	_, err := file.WriteAt([]byte(strconv.Itoa(os.Getpid())+"\n"), 0)
+	return errors.WithStack(err)
+}
@@ -0,0 +1,77 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vpphelper_test
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/vpphelper"
+)
+
+func TestStartContext_RootDirInUse(t *testing.T) {
+	_, _ = mockVPP(t)
+	fakeVPP(t, "exec sleep 60")
+	rootDir := t.TempDir()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	instance, err := vpphelper.StartContext(ctx, vpphelper.WithRootDir(rootDir))
+	require.NoError(t, err)
+
+	_, err = vpphelper.StartContext(ctx, vpphelper.WithRootDir(rootDir))
+	require.ErrorIs(t, err, vpphelper.ErrRootDirInUse)
+	var inUseErr *vpphelper.RootDirInUseError
+	require.ErrorAs(t, err, &inUseErr)
+	require.Equal(t, os.Getpid(), inUseErr.PID)
+	require.ErrorContains(t, err, strconv.Itoa(os.Getpid()))
+
+	require.NoError(t, instance.Stop(ctx))
+	instance, err = vpphelper.StartContext(ctx, vpphelper.WithRootDir(rootDir))
+	require.NoError(t, err)
+	require.NoError(t, instance.Stop(ctx))
+}
+
+func TestStartContext_StaleLock(t *testing.T) {
+	_, _ = mockVPP(t)
+	fakeVPP(t, "exec sleep 60")
+	rootDir := t.TempDir()
+
+	// The lock file names a process that is gone
+	dead := exec.Command("true")
+	require.NoError(t, dead.Run())
+	lockFile := filepath.Join(rootDir, "/var/run/vpp/vpphelper.lock")
+	require.NoError(t, os.MkdirAll(filepath.Dir(lockFile), 0o700))
+	require.NoError(t, os.WriteFile(lockFile, []byte(strconv.Itoa(dead.Process.Pid)), 0o600))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	instance, err := vpphelper.StartContext(ctx, vpphelper.WithRootDir(rootDir))
+	require.NoError(t, err)
+	require.Equal(t, lockFile, instance.Paths().LockFile)
+	lockPID, err := os.ReadFile(lockFile) // #nosec G304
+	require.NoError(t, err)
+	require.Equal(t, strconv.Itoa(os.Getpid())+"\n", string(lockPID))
+	require.NoError(t, instance.Stop(ctx))
+}
@@ -0,0 +1,173 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vpphelper
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/edwarnicke/log"
+)
+
+const (
+	logTailPollInterval = 100 * time.Millisecond
+)
+
+var matchAllRegexp = regexp.MustCompile("")
+
+// WithLogTail - follows the vpp log file from the start of the vpp process, logs every line like the output of
+// vpp and keeps the last lines in memory (see Instance.LogTail).  Truncation and rotation of the log file are
+// handled.
+func WithLogTail(lines int) Option {
+	return func(opt *option) {
+		opt.logTailLines = lines
+	}
+}
+
+// startLogTail - starts following the vpp log file if requested by o and returns a func that stops following it.
+// The tail is stopped only once vpp exited, so that its last lines are not lost.
+func (i *Instance) startLogTail(ctx context.Context, o *option) (stop func()) {
+	if o.logTailLines <= 0 {
+		return func() {}
+	}
+	tailCtx, tailCancel := context.WithCancel(context.WithoutCancel(ctx))
+	tailDone := make(chan struct{})
+	i.tail = newLogTail(ctx, i.paths.LogFile, o.logTailLines, o.logHooks)
+	offset := i.tail.offset()
+	go func() {
+		i.tail.follow(tailCtx, offset)
+		close(tailDone)
+	}()
+	return func() {
+		tailCancel()
+		<-tailDone
+	}
+}
+
+// lineRing - keeps the last lines it was given
+type lineRing struct {
+	max int
+
+	mu    sync.Mutex
+	lines []string
+}
+
+func newLineRing(lines int) *lineRing {
+	return &lineRing{max: lines}
+}
+
+// hook - returns a logHook that adds every line to r
+func (r *lineRing) hook() logHook {
+	return logHook{pattern: matchAllRegexp, hook: func(line LogLine) {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.lines = append(r.lines, line.Raw)
+		if len(r.lines) > r.max {
+			r.lines = r.lines[len(r.lines)-r.max:]
+		}
+	}}
+}
+
+// Lines - returns the last lines
+func (r *lineRing) Lines() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string{}, r.lines...)
+}
+
+// logTail - follows a log file, writing every new line to a logWriter and keeping the last lines in memory
+type logTail struct {
+	*lineRing
+	filename string
+	writer   *logWriter
+}
+
+func newLogTail(ctx context.Context, filename string, lines int, hooks []logHook) *logTail {
+	t := &logTail{
+		lineRing: newLineRing(lines),
+		filename: filename,
+	}
+	hooks = append(append([]logHook{}, hooks...), t.hook())
+	t.writer = newLogWriter(log.Entry(ctx).WithField("file", filepath.Base(filename)).WithTime(time.Time{}), hooks)
+	return t
+}
+
+// offset - returns the current size of the log file, so that lines written by an earlier vpp are skipped
+func (t *logTail) offset() int64 {
+	info, err := os.Stat(t.filename)
+	if err != nil {
+		return 0
+	}
+	return info.Size()
+}
+
+// follow - follows the log file starting at offset until ctx is done
+func (t *logTail) follow(ctx context.Context, offset int64) {
+	var f *os.File
+	defer func() {
+		if f != nil {
+			t.read(f)
+			_ = f.Close()
+		}
+		_ = t.writer.Close()
+	}()
+
+	ticker := time.NewTicker(logTailPollInterval)
+	defer ticker.Stop()
+	for {
+		if f == nil {
+			if opened, err := os.Open(t.filename); err == nil {
+				if _, err = opened.Seek(offset, io.SeekStart); err == nil {
+					f = opened
+				} else {
+					_ = opened.Close()
+				}
+			}
+		}
+		if f != nil {
+			t.read(f)
+			if info, err := f.Stat(); err == nil {
+				if position, seekErr := f.Seek(0, io.SeekCurrent); seekErr == nil && info.Size() < position {
+					log.Entry(ctx).Debugf("%s was truncated", t.filename)
+					_, _ = f.Seek(0, io.SeekStart)
+				}
+				if current, statErr := os.Stat(t.filename); statErr != nil || !os.SameFile(info, current) {
+					log.Entry(ctx).Debugf("%s was rotated", t.filename)
+					t.read(f)
+					_ = f.Close()
+					f = nil
+					offset = 0
+				}
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (t *logTail) read(f *os.File) {
+	_, _ = io.Copy(t.writer, f)
+}
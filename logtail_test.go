@@ -0,0 +1,59 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vpphelper_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/vpphelper"
+)
+
+func TestWithLogTail(t *testing.T) {
+	_, _ = mockVPP(t)
+	rootDir := t.TempDir()
+	logFile := filepath.Join(rootDir, "/var/log/vpp/vpp.log")
+	require.NoError(t, os.MkdirAll(filepath.Dir(logFile), 0o700))
+	require.NoError(t, os.WriteFile(logFile, []byte("written by an earlier vpp\n"), 0o600))
+
+	// vpp writes a line, the log is rotated, vpp writes a line, the log is truncated and vpp writes a line
+	fakeVPP(t, `log=`+logFile+`
+echo "line 1" >> $log
+sleep 0.3
+mv $log $log.1
+echo "line 2" >> $log
+sleep 0.3
+: > $log
+sleep 0.3
+echo "line 3" >> $log
+echo "line 4" >> $log`)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	instance, err := vpphelper.StartContext(ctx, vpphelper.WithRootDir(rootDir), vpphelper.WithLogTail(3))
+	require.NoError(t, err)
+
+	var exitErr *vpphelper.ExitError
+	require.ErrorAs(t, instance.Wait(), &exitErr)
+	require.Equal(t, vpphelper.ExitClean, exitErr.Kind)
+	require.Equal(t, []string{"line 2", "line 3", "line 4"}, instance.LogTail())
+}
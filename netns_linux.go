@@ -0,0 +1,62 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+
+package vpphelper
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+
+	"github.com/pkg/errors"
+	"golang.org/x/sys/unix"
+)
+
+// inNetNS - calls start on an OS thread switched to the network namespace at path, so that the processes it
+// starts run in that namespace.  An empty path calls start in the current namespace.
+func inNetNS(path string, start func()) error {
+	if path == "" {
+		start()
+		return nil
+	}
+	target, err := os.Open(path) // #nosec G304
+	if err != nil {
+		return errors.Wrapf(err, "unable to open netns %s", path)
+	}
+	defer func() { _ = target.Close() }()
+
+	runtime.LockOSThread()
+	current, err := os.Open(fmt.Sprintf("/proc/self/task/%d/ns/net", unix.Gettid()))
+	if err != nil {
+		runtime.UnlockOSThread()
+		return errors.Wrap(err, "unable to open the current netns")
+	}
+	defer func() { _ = current.Close() }()
+	if err = unix.Setns(int(target.Fd()), unix.CLONE_NEWNET); err != nil {
+		runtime.UnlockOSThread()
+		return errors.Wrapf(err, "unable to enter netns %s", path)
+	}
+
+	start()
+
+	// If the thread cannot be switched back it stays locked, so that the runtime does not reuse it
+	if unix.Setns(int(current.Fd()), unix.CLONE_NEWNET) == nil {
+		runtime.UnlockOSThread()
+	}
+	return nil
+}
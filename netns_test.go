@@ -0,0 +1,93 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+
+package vpphelper_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/vpphelper"
+)
+
+func TestWithNetNS(t *testing.T) {
+	// A process in a new network namespace provides the namespace
+	holder := exec.Command("unshare", "-n", "sleep", "60")
+	if err := holder.Start(); err != nil {
+		t.Skipf("unable to create a network namespace: %v", err)
+	}
+	defer func() {
+		_ = holder.Process.Kill()
+		_ = holder.Wait()
+	}()
+	netNS := fmt.Sprintf("/proc/%d/ns/net", holder.Process.Pid)
+	require.Eventually(t, func() bool {
+		self, _ := os.Readlink("/proc/self/ns/net")
+		ns, _ := os.Readlink(netNS)
+		return ns != "" && ns != self
+	}, time.Second, 10*time.Millisecond, "unshare did not create a network namespace")
+	wantNS, err := os.Readlink(netNS)
+	require.NoError(t, err)
+	callerNS, err := os.Readlink("/proc/self/ns/net")
+	require.NoError(t, err)
+
+	_, _ = mockVPP(t)
+	nsFile := filepath.Join(t.TempDir(), "ns")
+	fakeVPP(t, "readlink /proc/self/ns/net > "+nsFile+".tmp\nmv "+nsFile+".tmp "+nsFile+"\nexec sleep 60")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	instance, err := vpphelper.StartContext(ctx, vpphelper.WithRootDir(t.TempDir()), vpphelper.WithNetNS(netNS))
+	require.NoError(t, err)
+	defer func() { _ = instance.Stop(ctx) }()
+
+	var vppNS []byte
+	require.Eventually(t, func() bool {
+		vppNS, err = os.ReadFile(nsFile) // #nosec G304
+		return err == nil
+	}, time.Second, 10*time.Millisecond)
+	require.Equal(t, wantNS, strings.TrimSpace(string(vppNS)))
+
+	// Threads of the caller are back in their own namespace
+	tasks, err := filepath.Glob("/proc/self/task/*/ns/net")
+	require.NoError(t, err)
+	for _, task := range tasks {
+		ns, readErr := os.Readlink(task)
+		if readErr == nil {
+			require.Equal(t, callerNS, ns, task)
+		}
+	}
+}
+
+func TestWithNetNS_Invalid(t *testing.T) {
+	_, _ = mockVPP(t)
+	fakeVPP(t, "exec sleep 60")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_, err := vpphelper.StartContext(ctx, vpphelper.WithRootDir(t.TempDir()), vpphelper.WithNetNS(filepath.Join(t.TempDir(), "missing")))
+	require.ErrorContains(t, err, "unable to open netns")
+}
@@ -1,4 +1,5 @@
 // Copyright (c) 2020 Cisco and/or its affiliates.
+// Copyright (c) 2026 OpenInfra Foundation Europe.
 //
 // SPDX-License-Identifier: Apache-2.0
 //
@@ -16,14 +17,55 @@
 
 package vpphelper
 
+import "time"
+
 const (
 	// DefaultRootDir - Default value for RootDir
 	DefaultRootDir = ""
+	// DefaultVPPBinary - Default value for VPPBinary
+	DefaultVPPBinary = "vpp"
+	// DefaultGracePeriod - Default value for GracePeriod
+	DefaultGracePeriod = 5 * time.Second
+	// DefaultRestartInitialInterval - Default value for the interval before the first restart of a supervised vpp
+	DefaultRestartInitialInterval = 100 * time.Millisecond
+	// DefaultRestartMaxInterval - Default value for the max interval between restarts of a supervised vpp
+	DefaultRestartMaxInterval = 10 * time.Second
 )
 
 type option struct {
-	rootDir   string
-	vppConfig string
+	rootDir     string
+	vppConfig   string
+	gracePeriod time.Duration
+
+	configPolicy ConfigPolicy
+
+	vppBinary   string
+	extraArgs   []string
+	execWrapper []string
+	env         []string
+	netNS       string
+
+	cgroup    string
+	cpuSet    string
+	memoryMax int64
+	cpuWeight uint64
+
+	startupCLI        []string
+	startupCLITimeout time.Duration
+
+	logHooks     []logHook
+	logTailLines int
+
+	crashBundleDir     string
+	crashBundleArchive bool
+
+	attachOrStart    bool
+	keepRuntimeFiles bool
+
+	maxRestarts            int
+	restartWindow          time.Duration
+	restartInitialInterval time.Duration
+	restartMaxInterval     time.Duration
 }
 
 // Option - Option for use with vppagent.Start(...)
@@ -43,3 +85,73 @@ func WithVppConfig(vppConfig string) Option {
 		opt.vppConfig = vppConfig
 	}
 }
+
+// WithGracePeriod - time vpp is given to exit after SIGTERM before it is killed with SIGKILL
+func WithGracePeriod(gracePeriod time.Duration) Option {
+	return func(opt *option) {
+		opt.gracePeriod = gracePeriod
+	}
+}
+
+// WithRestart - supervises vpp: whenever it exits it is restarted, unless it was already restarted maxRestarts
+// times within the sliding window, in which case the instance gives up with a *CrashLoopError.
+func WithRestart(maxRestarts int, window time.Duration) Option {
+	return func(opt *option) {
+		opt.maxRestarts = maxRestarts
+		opt.restartWindow = window
+	}
+}
+
+// WithRestartBackoff - sets the interval before the first restart of a supervised vpp and the max interval
+// between restarts.  The interval is doubled for every restart within the restart window.
+func WithRestartBackoff(initialInterval, maxInterval time.Duration) Option {
+	return func(opt *option) {
+		opt.restartInitialInterval = initialInterval
+		opt.restartMaxInterval = maxInterval
+	}
+}
+
+// WithVPPBinary - name or path of the vpp binary to run
+func WithVPPBinary(vppBinary string) Option {
+	return func(opt *option) {
+		opt.vppBinary = vppBinary
+	}
+}
+
+// WithExtraArgs - additional command line arguments for vpp, passed after "-c <config file>"
+func WithExtraArgs(args ...string) Option {
+	return func(opt *option) {
+		opt.extraArgs = args
+	}
+}
+
+// WithExecWrapper - runs vpp under the given command, for example []string{"gdbserver", ":2345"}
+// or []string{"perf", "record", "-g", "--"}
+func WithExecWrapper(wrapper []string) Option {
+	return func(opt *option) {
+		opt.execWrapper = wrapper
+	}
+}
+
+// WithEnv - additional "key=value" environment variables for vpp
+func WithEnv(env ...string) Option {
+	return func(opt *option) {
+		opt.env = env
+	}
+}
+
+// WithNetNS - runs vpp in the network namespace at path, for example "/var/run/netns/<name>" or
+// "/proc/<pid>/ns/net".  The api, cli and stats sockets are unix sockets in the root dir and stay reachable from
+// the namespace of the caller.  Only supported on linux.
+func WithNetNS(path string) Option {
+	return func(opt *option) {
+		opt.netNS = path
+	}
+}
+
+// argv - returns the command line used to run vpp with configFile
+func (o *option) argv(configFile string) []string {
+	argv := append([]string{}, o.execWrapper...)
+	argv = append(argv, o.vppBinary, "-c", configFile)
+	return append(argv, o.extraArgs...)
+}
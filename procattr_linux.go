@@ -0,0 +1,38 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+
+package vpphelper
+
+import (
+	"os"
+	"syscall"
+
+	"github.com/edwarnicke/exechelper"
+)
+
+// deathSignalOptions - makes the kernel SIGKILL vpp once the process that started it dies.  Only the direct child
+// gets the signal, the rest of its process group is killed by the groupReaper.
+func deathSignalOptions() []*exechelper.Option {
+	return []*exechelper.Option{exechelper.WithOnDeathSignalChildren(syscall.SIGKILL)}
+}
+
+// inCgroup - makes the process start in the cgroup open as dir
+func inCgroup(attr *syscall.SysProcAttr, dir *os.File) {
+	attr.UseCgroupFD = true
+	attr.CgroupFD = int(dir.Fd())
+}
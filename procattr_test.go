@@ -0,0 +1,124 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+
+package vpphelper_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/vpphelper"
+)
+
+const parentHelperEnv = "VPPHELPER_TEST_PARENT_PIDFILE"
+
+// alive - returns true if the process with pid is running, zombies are taken for dead
+func alive(pid int) bool {
+	stat, err := os.ReadFile(fmt.Sprintf("/proc/%d/stat", pid))
+	if err != nil {
+		return false
+	}
+	fields := strings.Fields(string(stat[strings.LastIndexByte(string(stat), ')')+1:]))
+	return len(fields) > 0 && fields[0] != "Z"
+}
+
+// readPID - waits for filename to be written and returns the pid in it
+func readPID(t *testing.T, filename string) int {
+	var pid int
+	require.Eventually(t, func() bool {
+		data, err := os.ReadFile(filename) // #nosec G304
+		if err != nil {
+			return false
+		}
+		pid, err = strconv.Atoi(strings.TrimSpace(string(data)))
+		return err == nil
+	}, 5*time.Second, 10*time.Millisecond)
+	return pid
+}
+
+// forkingVPP - puts a fake vpp first in PATH that forks a child, writes its pid to childPIDFile and then runs body
+func forkingVPP(t *testing.T, childPIDFile, body string) {
+	fakeVPP(t, "sleep 60 &\necho $! > "+childPIDFile+".tmp\nmv "+childPIDFile+".tmp "+childPIDFile+"\n"+body)
+}
+
+func TestStartContext_ProcessGroup(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		body string
+	}{
+		{name: "Stop", body: "exec sleep 60"},
+		{name: "Exit", body: "exit 1"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, _ = mockVPP(t)
+			childPIDFile := filepath.Join(t.TempDir(), "child.pid")
+			forkingVPP(t, childPIDFile, tc.body)
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			instance, err := vpphelper.StartContext(ctx, vpphelper.WithRootDir(t.TempDir()))
+			require.NoError(t, err)
+			childPID := readPID(t, childPIDFile)
+
+			require.NoError(t, instance.Stop(ctx))
+			require.Eventually(t, func() bool { return !alive(childPID) }, time.Second, 10*time.Millisecond)
+		})
+	}
+}
+
+// TestStartContext_ParentDeath - a helper process starts vpp and is killed with SIGKILL, vpp and the processes it
+// forked have to die with it
+func TestStartContext_ParentDeath(t *testing.T) {
+	if pidFile := os.Getenv(parentHelperEnv); pidFile != "" {
+		runParentHelper(t, pidFile)
+		return
+	}
+
+	vppPIDFile := filepath.Join(t.TempDir(), "vpp.pid")
+	helper := exec.Command(os.Args[0], "-test.run=^TestStartContext_ParentDeath$") // #nosec G204
+	helper.Env = append(os.Environ(), parentHelperEnv+"="+vppPIDFile)
+	require.NoError(t, helper.Start())
+	defer func() { _ = helper.Process.Kill() }()
+
+	vppPID := readPID(t, vppPIDFile)
+	childPID := readPID(t, vppPIDFile+".child")
+	require.True(t, alive(vppPID))
+	require.True(t, alive(childPID))
+	require.NoError(t, helper.Process.Signal(syscall.SIGKILL))
+	_ = helper.Wait()
+	require.Eventually(t, func() bool { return !alive(vppPID) && !alive(childPID) }, 5*time.Second, 10*time.Millisecond)
+}
+
+func runParentHelper(t *testing.T, vppPIDFile string) {
+	_, _ = mockVPP(t)
+	forkingVPP(t, vppPIDFile+".child", "echo $$ > "+vppPIDFile+".tmp\nmv "+vppPIDFile+".tmp "+vppPIDFile+"\nexec sleep 60")
+	_, err := vpphelper.StartContext(context.Background(), vpphelper.WithRootDir(t.TempDir()))
+	require.NoError(t, err)
+	// Wait to be killed
+	time.Sleep(time.Minute)
+}
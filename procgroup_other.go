@@ -0,0 +1,47 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !unix
+
+package vpphelper
+
+import (
+	"os"
+	"syscall"
+)
+
+// groupProcAttr - process groups are only available on unix, vpp runs in the process group of the caller
+func groupProcAttr() *syscall.SysProcAttr {
+	return &syscall.SysProcAttr{}
+}
+
+// signalGroup - without process groups only the vpp process itself is signaled
+func signalGroup(process *os.Process, sig syscall.Signal) error {
+	if sig == syscall.SIGKILL {
+		return process.Kill()
+	}
+	return process.Signal(sig)
+}
+
+// groupReaper - without process groups there is no group to kill once the calling process dies
+type groupReaper struct{}
+
+// startGroupReaper - returns nil, there is nothing to reap without process groups
+func startGroupReaper(_ int) (*groupReaper, error) {
+	return nil, nil
+}
+
+func (r *groupReaper) stop() {}
@@ -0,0 +1,34 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build unix
+
+package vpphelper
+
+import (
+	"os"
+	"syscall"
+)
+
+// groupProcAttr - makes vpp run in its own process group, so that it and everything it forks can be signaled together
+func groupProcAttr() *syscall.SysProcAttr {
+	return &syscall.SysProcAttr{Setpgid: true}
+}
+
+// signalGroup - sends sig to every process in the process group of the vpp process
+func signalGroup(process *os.Process, sig syscall.Signal) error {
+	return syscall.Kill(-process.Pid, sig)
+}
@@ -0,0 +1,120 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vpphelper
+
+import (
+	"context"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.fd.io/govpp/api"
+	"go.fd.io/govpp/binapi/memclnt"
+	"go.fd.io/govpp/binapi/vpe"
+)
+
+const (
+	// DefaultReadinessTimeout - Default value for the timeout of each ReadinessCheck
+	DefaultReadinessTimeout = time.Second
+)
+
+// ReadinessCheck - checks whether vpp is ready to be used over a freshly established conn.
+// A Connection is reported as Connected only once all of its ReadinessChecks pass.
+type ReadinessCheck func(ctx context.Context, conn api.Connection) error
+
+// WithReadinessChecks - sets the checks vpp must pass before the connection is considered ready.
+// A failed check counts as a failed connection attempt: the connection is dropped and retried after backoff,
+// so that vpp gets the chance to finish loading its plugins and API message tables.
+func WithReadinessChecks(checks ...ReadinessCheck) DialOption {
+	return func(opt *dialOption) {
+		opt.readinessChecks = checks
+	}
+}
+
+// ControlPingCheck - returns a ReadinessCheck that requires a control_ping round trip to succeed
+func ControlPingCheck() ReadinessCheck {
+	return func(ctx context.Context, conn api.Connection) error {
+		return errors.Wrap(conn.Invoke(ctx, &memclnt.ControlPing{}, &memclnt.ControlPingReply{}), "control_ping failed")
+	}
+}
+
+// CompatibilityCheck - returns a ReadinessCheck that requires vpp to know all of msgs with matching CRCs
+func CompatibilityCheck(msgs ...api.Message) ReadinessCheck {
+	return func(_ context.Context, conn api.Connection) error {
+		channelProvider, ok := conn.(api.ChannelProvider)
+		if !ok {
+			return errors.Errorf("%T does not support compatibility checks", conn)
+		}
+		ch, err := channelProvider.NewAPIChannel()
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		defer ch.Close()
+		return errors.WithStack(ch.CheckCompatiblity(msgs...))
+	}
+}
+
+var versionRegexp = regexp.MustCompile(`(\d+)\.(\d+)`)
+
+// MinVersionCheck - returns a ReadinessCheck that requires vpp to be at least minVersion ("<major>.<minor>", i.e. "24.10")
+func MinVersionCheck(minVersion string) ReadinessCheck {
+	return func(ctx context.Context, conn api.Connection) error {
+		minMajor, minMinor, err := parseVersion(minVersion)
+		if err != nil {
+			return err
+		}
+		reply := &vpe.ShowVersionReply{}
+		if err = conn.Invoke(ctx, &vpe.ShowVersion{}, reply); err != nil {
+			return errors.Wrap(err, "show_version failed")
+		}
+		major, minor, err := parseVersion(reply.Version)
+		if err != nil {
+			return err
+		}
+		if major < minMajor || (major == minMajor && minor < minMinor) {
+			return errors.Errorf("vpp version %q is older than %q", reply.Version, minVersion)
+		}
+		return nil
+	}
+}
+
+func parseVersion(version string) (major, minor int, err error) {
+	match := versionRegexp.FindStringSubmatch(version)
+	if match == nil {
+		return 0, 0, errors.Errorf("unable to parse vpp version %q", version)
+	}
+	if major, err = strconv.Atoi(match[1]); err != nil {
+		return 0, 0, errors.WithStack(err)
+	}
+	if minor, err = strconv.Atoi(match[2]); err != nil {
+		return 0, 0, errors.WithStack(err)
+	}
+	return major, minor, nil
+}
+
+func runReadinessChecks(ctx context.Context, conn api.Connection, checks []ReadinessCheck) error {
+	for _, check := range checks {
+		checkCtx, cancel := context.WithTimeout(ctx, DefaultReadinessTimeout)
+		err := check(checkCtx, conn)
+		cancel()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vpphelper_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.fd.io/govpp/binapi/memclnt"
+	"go.fd.io/govpp/binapi/vpe"
+
+	"github.com/networkservicemesh/vpphelper"
+)
+
+func TestReadinessChecks(t *testing.T) {
+	vppAdapter, connects := mockVPP(t)
+	mockReply(vppAdapter, &vpe.ShowVersion{}, &vpe.ShowVersionReply{Version: "24.10-release"})
+	socket := filepath.Join(t.TempDir(), "api.sock")
+	require.NoError(t, os.WriteFile(socket, nil, 0o600))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	conn := vpphelper.DialContext(ctx, socket, vpphelper.WithReadinessChecks(
+		vpphelper.ControlPingCheck(),
+		vpphelper.CompatibilityCheck(&memclnt.ControlPing{}, &memclnt.ControlPingReply{}),
+		vpphelper.MinVersionCheck("24.06"),
+	))
+
+	require.NoError(t, conn.Invoke(ctx, &memclnt.ControlPing{}, &memclnt.ControlPingReply{}))
+	require.Equal(t, int32(1), atomic.LoadInt32(connects))
+}
+
+func TestReadinessChecks_Fail(t *testing.T) {
+	vppAdapter, connects := mockVPP(t)
+	mockReply(vppAdapter, &vpe.ShowVersion{}, &vpe.ShowVersionReply{Version: "v23.10-rc0~12-g3f2a"})
+	socket := filepath.Join(t.TempDir(), "api.sock")
+	require.NoError(t, os.WriteFile(socket, nil, 0o600))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	conn := vpphelper.DialContext(ctx, socket,
+		vpphelper.WithBackoff(time.Millisecond, time.Millisecond),
+		vpphelper.WithMaxAttempts(2),
+		vpphelper.WithReadinessChecks(vpphelper.MinVersionCheck("24.10")),
+	)
+
+	err := conn.Invoke(ctx, &memclnt.ControlPing{}, &memclnt.ControlPingReply{})
+	var dialErr *vpphelper.DialError
+	require.ErrorAs(t, err, &dialErr)
+	require.Len(t, dialErr.Attempts, 2)
+	require.ErrorContains(t, dialErr.Attempts[1].Err, `is older than "24.10"`)
+	require.Equal(t, int32(2), atomic.LoadInt32(connects))
+}
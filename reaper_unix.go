@@ -0,0 +1,63 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build unix
+
+package vpphelper
+
+import (
+	"os"
+	"os/exec"
+	"strconv"
+	"syscall"
+
+	"github.com/pkg/errors"
+)
+
+// reaperScript - blocks until its stdin is closed, then kills the process group given as $1
+const reaperScript = `read -r _; kill -9 -"$1"`
+
+// groupReaper - kills the process group of vpp with SIGKILL once the calling process dies, however it dies.  The
+// reaper reads from a pipe whose write end only the calling process holds, the kernel closes it when it dies.
+type groupReaper struct {
+	cmd      *exec.Cmd
+	lifeline *os.File
+}
+
+func startGroupReaper(pgid int) (*groupReaper, error) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	defer func() { _ = r.Close() }()
+	// #nosec G204 - pgid is the only argument and it is passed as $1
+	cmd := exec.Command("/bin/sh", "-c", reaperScript, "vpphelper-reaper", strconv.Itoa(pgid))
+	cmd.Stdin = r
+	// Signals for the process group of the caller, like SIGINT from a terminal, must not stop the reaper
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	if err = cmd.Start(); err != nil {
+		_ = w.Close()
+		return nil, errors.Wrap(err, "unable to start the process group reaper")
+	}
+	return &groupReaper{cmd: cmd, lifeline: w}, nil
+}
+
+// stop - stops the reaper without it killing the process group
+func (r *groupReaper) stop() {
+	_ = r.cmd.Process.Kill()
+	_ = r.cmd.Wait()
+	_ = r.lifeline.Close()
+}
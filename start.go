@@ -1,4 +1,5 @@
 // Copyright (c) 2020-2024 Cisco and/or its affiliates.
+// Copyright (c) 2026 OpenInfra Foundation Europe.
 //
 // SPDX-License-Identifier: Apache-2.0
 //
@@ -21,67 +22,88 @@ package vpphelper
 import (
 	"context"
 	"os"
-	"path"
 	"path/filepath"
-	"time"
 
-	"github.com/edwarnicke/exechelper"
+	"github.com/pkg/errors"
 	"go.fd.io/govpp/api"
-
-	"github.com/edwarnicke/log"
 )
 
 // StartAndDialContext - starts vpp
-// Stdout and Stderr for vpp are set to be log.Entry(ctx).Writer().
+// Every line vpp prints to Stdout and Stderr is logged to log.Entry(ctx) at the level parsed from it.
+// errCh receives the *ExitError of vpp and is closed once it exited; a clean exit is not sent.
 func StartAndDialContext(ctx context.Context, opts ...Option) (conn api.Connection, errCh <-chan error) {
-	o := &option{
-		rootDir:   DefaultRootDir,
-		vppConfig: DefaultVPPConfTemplate,
+	instance, err := StartContext(ctx, opts...)
+	if err != nil {
+		return nil, errorCh(err)
 	}
-	for _, opt := range opts {
-		opt(o)
+	return instance.Conn(), instanceErrCh(instance)
+}
+
+// StartAndDialWithStatsContext - starts vpp like StartAndDialContext and additionally dials its stats socket.
+// The stats connection is disconnected once vpp stopped.
+func StartAndDialWithStatsContext(ctx context.Context, opts ...Option) (conn api.Connection, statsConn StatsConnection, errCh <-chan error) {
+	instance, err := StartContext(ctx, opts...)
+	if err != nil {
+		return nil, nil, errorCh(err)
 	}
+	statsCtx, cancel := context.WithCancel(ctx)
+	go func() {
+		<-instance.Done()
+		cancel()
+	}()
+	return instance.Conn(), DialStatsContext(statsCtx, instance.Paths().StatsSocket), instanceErrCh(instance)
+}
 
-	if err := writeDefaultConfigFiles(ctx, o); err != nil {
-		errCh := make(chan error, 1)
-		errCh <- err
+// instanceErrCh - returns a channel that receives the exit error of the vpp process, if any, and is closed once
+// it exited.  A clean exit is not an error.
+func instanceErrCh(instance *Instance) <-chan error {
+	errCh := make(chan error, 1)
+	go func() {
+		var exitErr *ExitError
+		if err := instance.Wait(); err != nil && !(errors.As(err, &exitErr) && exitErr.Kind == ExitClean) {
+			errCh <- err
+		}
 		close(errCh)
-		return nil, errCh
+	}()
+	return errCh
+}
+
+func errorCh(err error) <-chan error {
+	errCh := make(chan error, 1)
+	errCh <- err
+	close(errCh)
+	return errCh
+}
+
+func newOption(opts ...Option) *option {
+	o := &option{
+		rootDir:     DefaultRootDir,
+		vppConfig:   DefaultVPPConfTemplate,
+		gracePeriod: DefaultGracePeriod,
+		vppBinary:   DefaultVPPBinary,
+
+		startupCLITimeout: DefaultStartupCLITimeout,
+
+		restartInitialInterval: DefaultRestartInitialInterval,
+		restartMaxInterval:     DefaultRestartMaxInterval,
 	}
-	// We need to reset time in logger to make sure that
-	// we don't use a static timestamp for a long-running process
-	logWriter := log.Entry(ctx).WithField("cmd", "vpp").WithTime(time.Time{}).Writer()
-	vppErrCh := exechelper.Start("vpp -c "+filepath.Join(o.rootDir, vppConfFilename),
-		exechelper.WithContext(ctx),
-		exechelper.WithStdout(logWriter),
-		exechelper.WithStderr(logWriter),
-	)
-	select {
-	case err := <-vppErrCh:
-		errCh := make(chan error, 1)
-		errCh <- err
-		close(errCh)
-		return nil, errCh
-	default:
+	for _, opt := range opts {
+		opt(o)
 	}
-
-	return DialContext(ctx, filepath.Join(o.rootDir, "/var/run/vpp/api.sock")), vppErrCh
+	return o
 }
 
 func writeDefaultConfigFiles(ctx context.Context, o *option) error {
 	configFiles := map[string]string{
-		vppConfFilename: NewVPPConfigFile(o.vppConfig, VPPConfigParameters{RootDir: o.rootDir, DataSize: vppDefaultDataSize}),
+		vppConfFilename: NewVPPConfigFile(o.vppConfig, VPPConfigParameters{
+			RootDir:       o.rootDir,
+			DataSize:      vppDefaultDataSize,
+			StartupConfig: startupCLIConfig(o),
+		}),
 	}
 	for filename, contents := range configFiles {
-		filename = filepath.Join(o.rootDir, filename)
-		if _, err := os.Stat(filename); os.IsNotExist(err) {
-			log.Entry(ctx).Infof("Configuration file: %q not found, using defaults", filename)
-			if err := os.MkdirAll(path.Dir(filename), 0o700); err != nil {
-				return err
-			}
-			if err := os.WriteFile(filename, []byte(contents), 0o600); err != nil {
-				return err
-			}
+		if err := writeConfigFile(ctx, o.configPolicy, filepath.Join(o.rootDir, filename), contents); err != nil {
+			return err
 		}
 	}
 	if err := os.MkdirAll(filepath.Join(o.rootDir, "/var/run/vpp"), 0o700); os.IsNotExist(err) {
@@ -90,5 +112,5 @@ func writeDefaultConfigFiles(ctx context.Context, o *option) error {
 	if err := os.MkdirAll(filepath.Join(o.rootDir, "/var/log/vpp"), 0o700); os.IsNotExist(err) {
 		return err
 	}
-	return nil
+	return writeStartupCLI(o)
 }
@@ -0,0 +1,214 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vpphelper
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// DefaultStartupCLITimeout - Default value for the time vpp is given to run the startup cli commands
+	DefaultStartupCLITimeout = 30 * time.Second
+	// startupCLIMarker - echoed before every startup cli command, so that its output can be told apart
+	startupCLIMarker = "@vpphelper-startup-cli"
+	startupCLIDone   = "done"
+)
+
+// WithStartupCLI - cli commands vpp runs at startup.  They are written to <rootDir>/etc/vpp/helper/startup.cli, which
+// is referenced by the startup-config of the rendered vpp.conf (a custom template has to use {{ .StartupConfig }}).
+// StartContext waits for the commands to complete and fails with a *StartupCLIError if any of them failed.  It
+// cannot be combined with WithAttachOrStart, an attached vpp does not run them.
+func WithStartupCLI(commands []string) Option {
+	return func(opt *option) {
+		opt.startupCLI = commands
+	}
+}
+
+// WithStartupCLITimeout - sets the time vpp is given to run the startup cli commands (see WithStartupCLI), a
+// timeout <= 0 is replaced by DefaultStartupCLITimeout
+func WithStartupCLITimeout(timeout time.Duration) Option {
+	if timeout <= 0 {
+		timeout = DefaultStartupCLITimeout
+	}
+	return func(opt *option) {
+		opt.startupCLITimeout = timeout
+	}
+}
+
+// StartupCLIError - returned by StartContext if startup cli commands failed (see WithStartupCLI)
+type StartupCLIError struct {
+	Errors []*CLIError
+}
+
+func (e *StartupCLIError) Error() string {
+	msgs := make([]string, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		msgs = append(msgs, err.Error())
+	}
+	return fmt.Sprintf("%d of the startup cli commands failed: %s", len(e.Errors), strings.Join(msgs, "; "))
+}
+
+// Unwrap - returns the *CLIError of every failed command
+func (e *StartupCLIError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+// startupCLIConfig - returns the startup cli file referenced by the vpp config, empty if there are no startup
+// cli commands
+func startupCLIConfig(o *option) string {
+	if len(o.startupCLI) == 0 {
+		return ""
+	}
+	return filepath.Join(o.rootDir, startupCLIFilename)
+}
+
+// writeStartupCLI - writes the startup cli file, with every command preceded by a marker
+func writeStartupCLI(o *option) error {
+	filename := startupCLIConfig(o)
+	if filename == "" {
+		return nil
+	}
+	var contents strings.Builder
+	for idx, command := range o.startupCLI {
+		_, _ = fmt.Fprintf(&contents, "echo %s %d\n%s\n", startupCLIMarker, idx, command)
+	}
+	_, _ = fmt.Fprintf(&contents, "echo %s %s\n", startupCLIMarker, startupCLIDone)
+	if err := os.MkdirAll(filepath.Dir(filename), 0o700); err != nil {
+		return errors.WithStack(err)
+	}
+	return errors.WithStack(os.WriteFile(filename, []byte(contents.String()), 0o600))
+}
+
+// checkStartupCLIConfig - makes sure the vpp config runs the startup cli file
+func checkStartupCLIConfig(configFile, startupCLIFile string) error {
+	config, err := os.ReadFile(filepath.Clean(configFile))
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	config = vppConfCommentLineRegexp.ReplaceAll(config, nil)
+	if !strings.Contains(string(config), startupCLIFile) {
+		return errors.Errorf("%s does not reference the startup cli file %s", configFile, startupCLIFile)
+	}
+	return nil
+}
+
+// startupCLIWatcher - collects the output of the startup cli commands from the output of vpp.  vpp stops running
+// the startup cli file at the first command that fails, so done is closed either after the last command or as soon
+// as the output of a command is that of a failed one.
+type startupCLIWatcher struct {
+	commands []string
+	done     chan struct{}
+
+	mu      sync.Mutex
+	current int
+	outputs [][]string
+	closed  bool
+}
+
+func newStartupCLIWatcher(commands []string) *startupCLIWatcher {
+	return &startupCLIWatcher{
+		commands: commands,
+		done:     make(chan struct{}),
+		current:  -1,
+		outputs:  make([][]string, len(commands)),
+	}
+}
+
+func (w *startupCLIWatcher) hook() logHook {
+	return logHook{pattern: matchAllRegexp, hook: func(line LogLine) {
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		if w.closed {
+			return
+		}
+		if marker, ok := strings.CutPrefix(strings.TrimSpace(line.Raw), startupCLIMarker+" "); ok {
+			if marker == startupCLIDone {
+				w.closed = true
+				close(w.done)
+				return
+			}
+			if idx, err := strconv.Atoi(marker); err == nil && idx >= 0 && idx < len(w.commands) {
+				w.current = idx
+			}
+			return
+		}
+		if w.current >= 0 {
+			w.outputs[w.current] = append(w.outputs[w.current], line.Raw)
+			if cliError(w.commands[w.current], strings.Join(w.outputs[w.current], "\n")) != nil {
+				w.closed = true
+				close(w.done)
+			}
+		}
+	}}
+}
+
+// err - returns a *StartupCLIError for the commands whose output is a cli error, nil if there are none
+func (w *startupCLIWatcher) err() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	var cliErrs []*CLIError
+	for idx, output := range w.outputs {
+		if err := cliError(w.commands[idx], strings.Join(output, "\n")); err != nil {
+			cliErrs = append(cliErrs, err)
+		}
+	}
+	if len(cliErrs) == 0 {
+		return nil
+	}
+	return &StartupCLIError{Errors: cliErrs}
+}
+
+// waitStartupCLI - waits for vpp to run the startup cli commands.  If they failed, vpp exited or did not run them
+// in time the Instance is stopped and an error is returned, a *StartupCLIError if a command failed.
+func (i *Instance) waitStartupCLI(ctx context.Context, timeout time.Duration) error {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	var err error
+	select {
+	case <-i.startupCLI.done:
+		err = i.startupCLI.err()
+	case <-i.done:
+		if cliErr := i.startupCLI.err(); cliErr != nil {
+			return cliErr
+		}
+		return errors.Wrap(i.err, "vpp exited before running the startup cli commands")
+	case <-ctx.Done():
+		err = errors.Wrap(ctx.Err(), "vpp did not run the startup cli commands")
+	case <-timer.C:
+		err = errors.Errorf("vpp did not run the startup cli commands within %s", timeout)
+	}
+	if cliErr := i.startupCLI.err(); cliErr != nil {
+		err = cliErr
+	}
+	if err != nil {
+		_ = i.Stop(context.WithoutCancel(ctx))
+	}
+	return err
+}
@@ -0,0 +1,120 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vpphelper_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/vpphelper"
+)
+
+// startupCLIVPP - puts a fake vpp first in PATH that runs the startup-config file of its config: "echo" commands
+// print their arguments, commands starting with "bad" fail and all the others succeed.  Like vpp it stops at the
+// first failing command.
+func startupCLIVPP(t *testing.T) {
+	fakeVPP(t, `cli=$(sed -n 's/^ *startup-config //p' "$2")
+while IFS= read -r line; do
+  case "$line" in
+    "echo "*) echo "${line#echo }" ;;
+    bad*) echo "$line: unknown input '$line'"; break ;;
+    *) echo "ok" ;;
+  esac
+done < "$cli"
+exec sleep 60`)
+}
+
+func TestWithStartupCLI(t *testing.T) {
+	_, _ = mockVPP(t)
+	startupCLIVPP(t)
+	rootDir := t.TempDir()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	commands := []string{"set interface state local0 up", "show version"}
+	instance, err := vpphelper.StartContext(ctx, vpphelper.WithRootDir(rootDir), vpphelper.WithStartupCLI(commands))
+	require.NoError(t, err)
+	defer func() { _ = instance.Stop(ctx) }()
+
+	startupCLI := filepath.Join(rootDir, "/etc/vpp/helper/startup.cli")
+	require.Contains(t, readFile(t, instance.Paths().ConfigFile), "startup-config "+startupCLI)
+	require.Contains(t, readFile(t, startupCLI), "set interface state local0 up\n")
+}
+
+func TestWithStartupCLI_Failed(t *testing.T) {
+	_, _ = mockVPP(t)
+	startupCLIVPP(t)
+
+	// The error is reported right away, without waiting for the startup cli to time out
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	commands := []string{"show version", "bad command", "show interface"}
+	_, err := vpphelper.StartContext(ctx, vpphelper.WithRootDir(t.TempDir()), vpphelper.WithStartupCLI(commands))
+
+	var startupErr *vpphelper.StartupCLIError
+	require.ErrorAs(t, err, &startupErr)
+	require.Len(t, startupErr.Errors, 1)
+	var cliErr *vpphelper.CLIError
+	require.ErrorAs(t, err, &cliErr)
+	require.Equal(t, "bad command", cliErr.Command)
+	require.Equal(t, "bad command: unknown input 'bad command'", cliErr.Output)
+	require.NoError(t, ctx.Err())
+}
+
+func TestWithStartupCLI_NotReferenced(t *testing.T) {
+	_, _ = mockVPP(t)
+	startupCLIVPP(t)
+	rootDir := t.TempDir()
+	configFile := filepath.Join(rootDir, "/etc/vpp/helper/vpp.conf")
+	require.NoError(t, os.MkdirAll(filepath.Dir(configFile), 0o700))
+	require.NoError(t, os.WriteFile(configFile, []byte("unix { nodaemon }\n"), 0o600))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_, err := vpphelper.StartContext(ctx, vpphelper.WithRootDir(rootDir), vpphelper.WithStartupCLI([]string{"show version"}))
+	require.ErrorContains(t, err, "does not reference the startup cli file")
+}
+
+func TestWithStartupCLI_Timeout(t *testing.T) {
+	_, _ = mockVPP(t)
+	fakeVPP(t, "exec sleep 60")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_, err := vpphelper.StartContext(ctx,
+		vpphelper.WithRootDir(t.TempDir()),
+		vpphelper.WithStartupCLI([]string{"show version"}),
+		vpphelper.WithStartupCLITimeout(50*time.Millisecond),
+	)
+	require.EqualError(t, err, "vpp did not run the startup cli commands within 50ms")
+}
+
+func TestWithStartupCLI_AttachOrStart(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_, err := vpphelper.StartContext(ctx,
+		vpphelper.WithRootDir(t.TempDir()),
+		vpphelper.WithStartupCLI([]string{"show version"}),
+		vpphelper.WithAttachOrStart(),
+	)
+	require.ErrorContains(t, err, "WithStartupCLI cannot be combined with WithAttachOrStart")
+}
@@ -0,0 +1,67 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vpphelper
+
+import (
+	"fmt"
+	"time"
+)
+
+// ConnectionState - state of a Connection returned by DialContext
+type ConnectionState int
+
+const (
+	// Connecting - the initial dial has not finished yet
+	Connecting ConnectionState = iota
+	// Connected - the connection to vpp is established
+	Connected
+	// Disconnected - the connection to vpp was lost and is being re-established
+	Disconnected
+	// Failed - the connection gave up, Err() returns the reason
+	Failed
+	// Unhealthy - vpp stopped answering keepalives, the connection is about to be re-established
+	Unhealthy
+)
+
+func (s ConnectionState) String() string {
+	switch s {
+	case Connecting:
+		return "Connecting"
+	case Connected:
+		return "Connected"
+	case Disconnected:
+		return "Disconnected"
+	case Failed:
+		return "Failed"
+	case Unhealthy:
+		return "Unhealthy"
+	default:
+		return fmt.Sprintf("UnknownState(%d)", int(s))
+	}
+}
+
+// ConnectionEvent - notification about a state transition of a Connection
+type ConnectionEvent struct {
+	// Timestamp - time of the transition
+	Timestamp time.Time
+	// State - the new state
+	State ConnectionState
+	// Error - the error that caused the transition, if any
+	Error error
+}
+
+const subscriberBufSize = 16
@@ -0,0 +1,32 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vpphelper
+
+import "go.fd.io/govpp/api"
+
+// StatsConnection - api.StatsProvider for the vpp stats segment
+type StatsConnection interface {
+	api.StatsProvider
+	// Ready - returns a channel that is closed once the stats segment is connected or the dial failed
+	Ready() <-chan struct{}
+	// Err - returns the error the dial failed with, ErrClosed after Disconnect, the error of the ctx once it is done,
+	// nil otherwise
+	Err() error
+	// Disconnect - disconnects from the stats segment and stops a pending dial.  Calls made afterwards fail with
+	// ErrClosed.
+	Disconnect()
+}
@@ -0,0 +1,142 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+
+package vpphelper_test
+
+import (
+	"context"
+	"encoding/binary"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.fd.io/govpp/api"
+
+	"github.com/networkservicemesh/vpphelper"
+)
+
+func TestDialStatsContext_DialTimeout(t *testing.T) {
+	socket := filepath.Join(t.TempDir(), "stats.sock")
+
+	statsConn := vpphelper.DialStatsContext(context.Background(), socket, vpphelper.WithDialTimeout(10*time.Millisecond))
+	defer statsConn.Disconnect()
+
+	err := statsConn.GetSystemStats(&api.SystemStats{})
+	var dialErr *vpphelper.DialError
+	require.ErrorAs(t, err, &dialErr)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Equal(t, err, statsConn.Err())
+}
+
+func TestDialStatsContext_MaxAttempts(t *testing.T) {
+	socket := filepath.Join(t.TempDir(), "stats.sock")
+	// Not a socket, so connecting to it fails
+	require.NoError(t, os.WriteFile(socket, nil, 0o600))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	statsConn := vpphelper.DialStatsContext(ctx, socket,
+		vpphelper.WithBackoff(time.Millisecond, time.Millisecond),
+		vpphelper.WithMaxAttempts(2),
+	)
+	defer statsConn.Disconnect()
+
+	<-statsConn.Ready()
+	var dialErr *vpphelper.DialError
+	require.ErrorAs(t, statsConn.Err(), &dialErr)
+	require.Len(t, dialErr.Attempts, 2)
+}
+
+// fakeStatsSocket - serves a stats segment holding nothing but a version 2 header on a stats socket, the way vpp
+// hands it out: as a file descriptor sent over a SOCK_SEQPACKET unix socket.  It returns the socket and the file
+// backing the segment.
+func fakeStatsSocket(t *testing.T) (socket, segment string) {
+	dir := t.TempDir()
+	segment = filepath.Join(dir, "stats-segment")
+	header := make([]byte, os.Getpagesize())
+	binary.LittleEndian.PutUint64(header[0:], 2)  // version
+	binary.LittleEndian.PutUint64(header[16:], 1) // epoch
+	require.NoError(t, os.WriteFile(segment, header, 0o600))
+
+	socket = filepath.Join(dir, "stats.sock")
+	listener, err := net.ListenUnix("unixpacket", &net.UnixAddr{Net: "unixpacket", Name: socket})
+	require.NoError(t, err)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for {
+			conn, err := listener.AcceptUnix()
+			if err != nil {
+				return
+			}
+			if f, err := os.Open(segment); err == nil { // #nosec G304
+				_, _, _ = conn.WriteMsgUnix([]byte{0}, syscall.UnixRights(int(f.Fd())), nil)
+				_ = f.Close()
+			}
+			_ = conn.Close()
+		}
+	}()
+	t.Cleanup(func() {
+		_ = listener.Close()
+		<-done
+	})
+	return socket, segment
+}
+
+// mapped - returns whether filename is mapped into the memory of this process
+func mapped(t *testing.T, filename string) bool {
+	return strings.Contains(readFile(t, "/proc/self/maps"), filename)
+}
+
+func TestDialStatsContext(t *testing.T) {
+	socket, segment := fakeStatsSocket(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	statsConn := vpphelper.DialStatsContext(ctx, socket)
+	defer statsConn.Disconnect()
+
+	<-statsConn.Ready()
+	require.NoError(t, statsConn.Err())
+	require.True(t, mapped(t, segment))
+
+	// the stats segment is unmapped once ctx is done
+	cancel()
+	require.Eventually(t, func() bool { return !mapped(t, segment) }, time.Second, 10*time.Millisecond)
+	require.ErrorIs(t, statsConn.GetSystemStats(&api.SystemStats{}), context.Canceled)
+	require.ErrorIs(t, statsConn.Err(), context.Canceled)
+}
+
+func TestDialStatsContext_Disconnect(t *testing.T) {
+	socket, segment := fakeStatsSocket(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	statsConn := vpphelper.DialStatsContext(ctx, socket)
+	<-statsConn.Ready()
+	require.NoError(t, statsConn.Err())
+
+	statsConn.Disconnect()
+	require.False(t, mapped(t, segment))
+	require.ErrorIs(t, statsConn.GetSystemStats(&api.SystemStats{}), vpphelper.ErrClosed)
+}
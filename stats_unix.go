@@ -0,0 +1,164 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !windows
+
+package vpphelper
+
+import (
+	"context"
+	"sync"
+
+	"github.com/edwarnicke/log"
+	"github.com/pkg/errors"
+	"go.fd.io/govpp/adapter/statsclient"
+	"go.fd.io/govpp/api"
+	"go.fd.io/govpp/core"
+)
+
+type statsConnection struct {
+	*core.StatsConnection
+	ctx       context.Context
+	cancel    context.CancelFunc
+	closeOnce sync.Once
+	closed    chan struct{}
+	ready     chan struct{}
+	done      chan struct{}
+	err       error
+}
+
+// DialStatsContext - Dials the vpp stats socket and returns a StatsConnection
+// DialStatsContext is 'lazy' in the same way DialContext is: until the stats segment is connected, calls
+// block until it is or the dial fails.  The DialOptions controlling backoff and limits are honored, readiness
+// checks and keepalives only apply to DialContext and are ignored.
+// Once connected, govpp remaps the stats segment whenever vpp recreates the socket.  The stats segment is
+// disconnected once ctx is done.
+func DialStatsContext(ctx context.Context, filename string, opts ...DialOption) StatsConnection {
+	ctx, cancel := context.WithCancel(ctx)
+	c := &statsConnection{
+		ctx:    ctx,
+		cancel: cancel,
+		closed: make(chan struct{}),
+		ready:  make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+	go c.connect(ctx, filename, newDialOption(opts...))
+	return c
+}
+
+func (c *statsConnection) connect(ctx context.Context, filename string, o *dialOption) {
+	defer close(c.done)
+	c.err = dial(ctx, filename, o, func(context.Context) error {
+		statsConn, err := core.ConnectStats(statsclient.NewStatsClient(filename))
+		if err != nil {
+			return err
+		}
+		c.StatsConnection = statsConn
+		return nil
+	})
+	close(c.ready)
+	if c.err != nil {
+		log.Entry(ctx).Debugf("unable to connect to stats segment at %s due to %+v", filename, c.err)
+		return
+	}
+	<-ctx.Done()
+	c.StatsConnection.Disconnect()
+}
+
+// wait - waits until the stats segment is connected and returns an error if the dial failed, the connection was
+// disconnected or its ctx is done
+func (c *statsConnection) wait() error {
+	<-c.ready
+	select {
+	case <-c.closed:
+		return ErrClosed
+	default:
+	}
+	if c.err != nil {
+		return c.err
+	}
+	select {
+	case <-c.ctx.Done():
+		return errors.WithStack(c.ctx.Err())
+	default:
+		return nil
+	}
+}
+
+func (c *statsConnection) GetSystemStats(stats *api.SystemStats) error {
+	if err := c.wait(); err != nil {
+		return err
+	}
+	return c.StatsConnection.GetSystemStats(stats)
+}
+
+func (c *statsConnection) GetNodeStats(stats *api.NodeStats) error {
+	if err := c.wait(); err != nil {
+		return err
+	}
+	return c.StatsConnection.GetNodeStats(stats)
+}
+
+func (c *statsConnection) GetInterfaceStats(stats *api.InterfaceStats) error {
+	if err := c.wait(); err != nil {
+		return err
+	}
+	return c.StatsConnection.GetInterfaceStats(stats)
+}
+
+func (c *statsConnection) GetErrorStats(stats *api.ErrorStats) error {
+	if err := c.wait(); err != nil {
+		return err
+	}
+	return c.StatsConnection.GetErrorStats(stats)
+}
+
+func (c *statsConnection) GetBufferStats(stats *api.BufferStats) error {
+	if err := c.wait(); err != nil {
+		return err
+	}
+	return c.StatsConnection.GetBufferStats(stats)
+}
+
+func (c *statsConnection) GetMemoryStats(stats *api.MemoryStats) error {
+	if err := c.wait(); err != nil {
+		return err
+	}
+	return c.StatsConnection.GetMemoryStats(stats)
+}
+
+func (c *statsConnection) Ready() <-chan struct{} {
+	return c.ready
+}
+
+func (c *statsConnection) Err() error {
+	select {
+	case <-c.ready:
+		return c.wait()
+	default:
+		return nil
+	}
+}
+
+func (c *statsConnection) Disconnect() {
+	c.closeOnce.Do(func() {
+		close(c.closed)
+		c.cancel()
+	})
+	<-c.done
+}
+
+var _ StatsConnection = &statsConnection{}
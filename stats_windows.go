@@ -0,0 +1,76 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package vpphelper
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"go.fd.io/govpp/api"
+)
+
+// errStatsUnsupported - the govpp stats client receives the stats segment over a unix socket, which windows lacks
+var errStatsUnsupported = errors.New("the vpp stats segment is not supported on windows")
+
+// DialStatsContext - the stats segment is not supported on windows, every call on the returned StatsConnection fails
+func DialStatsContext(_ context.Context, _ string, _ ...DialOption) StatsConnection {
+	ready := make(chan struct{})
+	close(ready)
+	return &unsupportedStatsConnection{ready: ready}
+}
+
+type unsupportedStatsConnection struct {
+	ready chan struct{}
+}
+
+func (c *unsupportedStatsConnection) GetSystemStats(*api.SystemStats) error {
+	return errStatsUnsupported
+}
+
+func (c *unsupportedStatsConnection) GetNodeStats(*api.NodeStats) error {
+	return errStatsUnsupported
+}
+
+func (c *unsupportedStatsConnection) GetInterfaceStats(*api.InterfaceStats) error {
+	return errStatsUnsupported
+}
+
+func (c *unsupportedStatsConnection) GetErrorStats(*api.ErrorStats) error {
+	return errStatsUnsupported
+}
+
+func (c *unsupportedStatsConnection) GetBufferStats(*api.BufferStats) error {
+	return errStatsUnsupported
+}
+
+func (c *unsupportedStatsConnection) GetMemoryStats(*api.MemoryStats) error {
+	return errStatsUnsupported
+}
+
+func (c *unsupportedStatsConnection) Ready() <-chan struct{} {
+	return c.ready
+}
+
+func (c *unsupportedStatsConnection) Err() error {
+	return errStatsUnsupported
+}
+
+func (c *unsupportedStatsConnection) Disconnect() {}
+
+var _ StatsConnection = &unsupportedStatsConnection{}
@@ -1,4 +1,5 @@
 // Copyright (c) 2023-2024 Cisco and/or its affiliates.
+// Copyright (c) 2026 OpenInfra Foundation Europe.
 //
 // SPDX-License-Identifier: Apache-2.0
 //
@@ -25,6 +26,8 @@ import (
 type VPPConfigParameters struct {
 	DataSize int
 	RootDir  string
+	// StartupConfig - file with cli commands vpp runs at startup, empty if there are none
+	StartupConfig string
 }
 
 // NewVPPConfigFile creates new VPP config based on parameters
@@ -1,5 +1,5 @@
 // Copyright (c) 2020-2024 Cisco and/or its affiliates.
-// Copyright (c) 2025 OpenInfra Foundation Europe.
+// Copyright (c) 2025-2026 OpenInfra Foundation Europe.
 //
 // SPDX-License-Identifier: Apache-2.0
 //
@@ -18,7 +18,13 @@
 package vpphelper
 
 const (
-	vppConfFilename = "/etc/vpp/helper/vpp.conf"
+	vppConfFilename    = "/etc/vpp/helper/vpp.conf"
+	apiSockFilename    = "/var/run/vpp/api.sock"
+	cliSockFilename    = "/var/run/vpp/cli.sock"
+	statsSockFilename  = "/var/run/vpp/stats.sock"
+	logFilename        = "/var/log/vpp/vpp.log"
+	lockFilename       = "/var/run/vpp/vpphelper.lock"
+	startupCLIFilename = "/etc/vpp/helper/startup.cli"
 
 	// DefaultVPPConfTemplate - template for VPP config
 	DefaultVPPConfTemplate = `unix {
@@ -27,6 +33,9 @@ const (
   full-coredump
   cli-listen {{ .RootDir }}/var/run/vpp/cli.sock
   gid vpp
+{{- if .StartupConfig }}
+  startup-config {{ .StartupConfig }}
+{{- end }}
 }
 
 buffers {
@@ -0,0 +1,149 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vpphelper
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+// LogLine - a line of vpp output
+type LogLine struct {
+	// Time - timestamp as printed by vpp, if any
+	Time string
+	// Level - vpp log level ("emerg", "alert", "crit", "err", "warn", "notice", "info" or "debug"), if any
+	Level string
+	// Class - vpp log class (i.e. "vlib/plugin") or the name of the function that printed the line, if any
+	Class string
+	// Message - the line without timestamp, level and class
+	Message string
+	// Raw - the line as printed by vpp
+	Raw string
+}
+
+// LogHook - function called for lines of vpp output.  Hooks are called synchronously and must not block.
+type LogHook func(line LogLine)
+
+// WithLogHook - calls hook for every line of vpp output matching pattern, for example
+// regexp.MustCompile(`(?i)plugin.*(fail|error)`)
+func WithLogHook(pattern *regexp.Regexp, hook LogHook) Option {
+	return func(opt *option) {
+		opt.logHooks = append(opt.logHooks, logHook{pattern: pattern, hook: hook})
+	}
+}
+
+type logHook struct {
+	pattern *regexp.Regexp
+	hook    LogHook
+}
+
+var (
+	// 2024/05/06 10:10:10:123 warn       vlib/plugin    message
+	timestampedLogLineRegexp = regexp.MustCompile(`^(\d{4}/\d{2}/\d{2} \d{2}:\d{2}:\d{2}:\d{3}) +(emerg|alert|crit|err|warn|notice|info|debug) +(\S+) +(.*)$`)
+	// vpp[42]: load_one_plugin:189: message
+	// vpp[42]: dpdk: message
+	classLogLineRegexp = regexp.MustCompile(`^(?:vpp\[\d+\]: )?([\w/.-]+?)(?::\d+)?: (.*)$`)
+)
+
+// ParseLogLine - parses a line of vpp output
+func ParseLogLine(raw string) LogLine {
+	if match := timestampedLogLineRegexp.FindStringSubmatch(raw); match != nil {
+		return LogLine{Time: match[1], Level: match[2], Class: match[3], Message: match[4], Raw: raw}
+	}
+	if match := classLogLineRegexp.FindStringSubmatch(raw); match != nil {
+		return LogLine{Class: match[1], Message: match[2], Raw: raw}
+	}
+	return LogLine{Message: raw, Raw: raw}
+}
+
+func logrusLevel(level string) logrus.Level {
+	switch level {
+	case "emerg", "alert", "crit", "err":
+		return logrus.ErrorLevel
+	case "warn":
+		return logrus.WarnLevel
+	case "debug":
+		return logrus.DebugLevel
+	default:
+		return logrus.InfoLevel
+	}
+}
+
+// logWriter - io.WriteCloser that logs every line of vpp output at the level parsed from it
+type logWriter struct {
+	entry *logrus.Entry
+	hooks []logHook
+
+	mu  sync.Mutex
+	buf []byte
+}
+
+func newLogWriter(entry *logrus.Entry, hooks []logHook) *logWriter {
+	return &logWriter{
+		entry: entry,
+		hooks: hooks,
+	}
+}
+
+func (w *logWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.buf = append(w.buf, p...)
+	for {
+		idx := bytes.IndexByte(w.buf, '\n')
+		if idx < 0 {
+			break
+		}
+		w.log(string(w.buf[:idx]))
+		w.buf = w.buf[idx+1:]
+	}
+	return len(p), nil
+}
+
+// Close - logs the last line if it was not terminated by a newline
+func (w *logWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.log(string(w.buf))
+	w.buf = nil
+	return nil
+}
+
+func (w *logWriter) log(raw string) {
+	raw = strings.TrimRight(raw, "\r")
+	if raw == "" {
+		return
+	}
+	line := ParseLogLine(raw)
+	entry := w.entry
+	if line.Class != "" {
+		entry = entry.WithField("class", line.Class)
+	}
+	if line.Time != "" {
+		entry = entry.WithField("vppTime", line.Time)
+	}
+	entry.Log(logrusLevel(line.Level), line.Message)
+	for _, h := range w.hooks {
+		if h.pattern.MatchString(raw) {
+			h.hook(line)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vpphelper_test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/vpphelper"
+)
+
+func TestParseLogLine(t *testing.T) {
+	for _, tc := range []struct {
+		raw      string
+		expected vpphelper.LogLine
+	}{
+		{
+			raw: "2024/05/06 10:10:10:123 warn       vlib/plugin    plugin foo_plugin.so failed to load",
+			expected: vpphelper.LogLine{
+				Time:    "2024/05/06 10:10:10:123",
+				Level:   "warn",
+				Class:   "vlib/plugin",
+				Message: "plugin foo_plugin.so failed to load",
+			},
+		},
+		{
+			raw:      "vpp[42]: load_one_plugin:189: Loaded plugin: acl_plugin.so",
+			expected: vpphelper.LogLine{Class: "load_one_plugin", Message: "Loaded plugin: acl_plugin.so"},
+		},
+		{
+			raw:      "vpp[42]: dpdk: EAL init args",
+			expected: vpphelper.LogLine{Class: "dpdk", Message: "EAL init args"},
+		},
+		{
+			raw:      "unformatted output",
+			expected: vpphelper.LogLine{Message: "unformatted output"},
+		},
+	} {
+		tc.expected.Raw = tc.raw
+		require.Equal(t, tc.expected, vpphelper.ParseLogLine(tc.raw))
+	}
+}
+
+func TestWithLogHook(t *testing.T) {
+	_, _ = mockVPP(t)
+	fakeVPP(t, `echo "vpp[42]: load_one_plugin:189: Loaded plugin: acl_plugin.so"
+echo "2024/05/06 10:10:10:123 err        vlib/plugin    plugin foo_plugin.so failed to load" >&2
+exec sleep 60`)
+
+	lines := make(chan vpphelper.LogLine, 10)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	instance, err := vpphelper.StartContext(ctx,
+		vpphelper.WithRootDir(t.TempDir()),
+		vpphelper.WithLogHook(regexp.MustCompile(`plugin .* failed`), func(line vpphelper.LogLine) {
+			lines <- line
+		}),
+	)
+	require.NoError(t, err)
+	defer func() { _ = instance.Stop(ctx) }()
+
+	select {
+	case line := <-lines:
+		require.Equal(t, "err", line.Level)
+		require.Equal(t, "vlib/plugin", line.Class)
+	case <-ctx.Done():
+		require.FailNow(t, "hook was not called")
+	}
+}